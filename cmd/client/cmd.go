@@ -18,11 +18,13 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"oxia/cmd/client/common"
+	"oxia/cmd/client/count"
 	"oxia/cmd/client/delete"
 	"oxia/cmd/client/get"
 	"oxia/cmd/client/list"
 	"oxia/cmd/client/notifications"
 	"oxia/cmd/client/put"
+	"oxia/cmd/flag"
 	"oxia/kubernetes"
 	"oxia/oxia"
 )
@@ -42,10 +44,13 @@ func init() {
 	Cmd.PersistentFlags().DurationVar(&common.Config.BatchLinger, "batch-linger", oxia.DefaultBatchLinger, "Max time requests will be staged to be included in a batch")
 	Cmd.PersistentFlags().IntVar(&common.Config.MaxRequestsPerBatch, "max-requests-per-batch", oxia.DefaultMaxRequestsPerBatch, "Maximum requests per batch")
 	Cmd.PersistentFlags().DurationVar(&common.Config.RequestTimeout, "request-timeout", oxia.DefaultRequestTimeout, "Requests timeout")
+	flag.ClientTLS(Cmd, "", &common.Config.TLS)
+	flag.ClientAuth(Cmd, &common.Config.AuthToken)
 
 	Cmd.AddCommand(put.Cmd)
 	Cmd.AddCommand(delete.Cmd)
 	Cmd.AddCommand(get.Cmd)
 	Cmd.AddCommand(list.Cmd)
+	Cmd.AddCommand(count.Cmd)
 	Cmd.AddCommand(notifications.Cmd)
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"oxia/cmd/client/count"
 	"oxia/cmd/client/delete"
 	"oxia/cmd/client/get"
 	"oxia/cmd/client/list"
@@ -137,6 +138,14 @@ func TestClientCmd(t *testing.T) {
 			"\\{\"keys\":\\[\"k-put\",\"k-put-binary-ok\"\\]\\}",
 			"^$",
 		},
+		{"count", []string{"count", "--key-min", "a", "--key-max", "z"}, "", nil,
+			"\\{\"count\":2,\"total_value_size\":7\\}",
+			"^$",
+		},
+		{"count-none", []string{"count", "--key-min", "XXX", "--key-max", "XXY"}, "", nil,
+			"\\{\"count\":0,\"total_value_size\":0\\}",
+			"^$",
+		},
 		{"delete", []string{"delete", "-k", "k-put-binary-ok"}, "", nil,
 			"\\{\\}",
 			"^$",
@@ -182,6 +191,7 @@ func TestClientCmd(t *testing.T) {
 			put.Config.Reset()
 			get.Config.Reset()
 			list.Config.Reset()
+			count.Config.Reset()
 			delete.Config.Reset()
 
 			stdin.WriteString(test.stdin)
@@ -15,6 +15,7 @@
 package common
 
 import (
+	"oxia/common/security"
 	"oxia/oxia"
 	"time"
 )
@@ -29,13 +30,26 @@ type ClientConfig struct {
 	BatchLinger         time.Duration
 	MaxRequestsPerBatch int
 	RequestTimeout      time.Duration
+	TLS                 security.TLSOptions
+	AuthToken           string
 }
 
 func (config *ClientConfig) NewClient() (oxia.AsyncClient, error) {
-	return oxia.NewAsyncClient(Config.ServiceAddr,
+	tlsConf, err := Config.TLS.MakeClientTLSConf()
+	if err != nil {
+		return nil, err
+	}
+
+	options := []oxia.ClientOption{
 		oxia.WithBatchLinger(Config.BatchLinger),
 		oxia.WithRequestTimeout(Config.RequestTimeout),
 		oxia.WithMaxRequestsPerBatch(Config.MaxRequestsPerBatch),
 		oxia.WithNamespace(Config.Namespace),
-	)
+		oxia.WithTLS(tlsConf),
+	}
+	if Config.AuthToken != "" {
+		options = append(options, oxia.WithAuthentication(oxia.NewTokenAuthentication(Config.AuthToken)))
+	}
+
+	return oxia.NewAsyncClient(Config.ServiceAddr, options...)
 }
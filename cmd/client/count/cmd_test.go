@@ -0,0 +1,126 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package count
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"oxia/cmd/client/common"
+	"oxia/oxia"
+	"testing"
+)
+
+func Test_exec(t *testing.T) {
+	for _, test := range []struct {
+		name            string
+		stdin           string
+		flags           flags
+		expectedErr     error
+		expectedQueries []common.Query
+	}{
+		{"range",
+			"",
+			flags{
+				keyMinimums: []string{"a"},
+				keyMaximums: []string{"b"},
+			},
+			nil,
+			[]common.Query{Query{
+				KeyMinimum: "a",
+				KeyMaximum: "b",
+			}}},
+		{"stdin",
+			"{\"key_minimum\":\"a\",\"key_maximum\":\"b\"}\n{\"key_minimum\":\"x\",\"key_maximum\":\"y\"}\n",
+			flags{},
+			nil,
+			[]common.Query{Query{
+				KeyMinimum: "a",
+				KeyMaximum: "b",
+			}, Query{
+				KeyMinimum: "x",
+				KeyMaximum: "y",
+			}}},
+		{"range-no-min",
+			"",
+			flags{
+				keyMaximums: []string{"b"},
+			},
+			ErrorExpectedRangeInconsistent,
+			nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			in := bytes.NewBufferString(test.stdin)
+			queue := fakeQueryQueue{}
+			err := _exec(test.flags, in, &queue)
+			assert.Equal(t, test.expectedQueries, queue.queries)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOutputMarshal(t *testing.T) {
+	result, err := json.Marshal(Output{Count: 3, TotalValueSize: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"count\":3,\"total_value_size\":42}", string(result))
+}
+
+func TestCall_Complete(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		clientResult oxia.CountRangeResult
+		expected     any
+	}{
+		{
+			"count",
+			oxia.CountRangeResult{
+				Count:          3,
+				TotalValueSize: 42,
+			}, Output{
+				Count:          3,
+				TotalValueSize: 42,
+			},
+		},
+		{
+			"error",
+			oxia.CountRangeResult{
+				Err: errors.New("error"),
+			}, common.OutputError{
+				Err: "error",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			results := make(chan oxia.CountRangeResult, 1)
+			results <- test.clientResult
+			call := Call{
+				clientCall: results,
+			}
+			assert.Equal(t, test.expected, <-call.Complete())
+		})
+	}
+}
+
+type fakeQueryQueue struct {
+	queries []common.Query
+}
+
+func (q *fakeQueryQueue) Add(query common.Query) {
+	if q.queries == nil {
+		q.queries = []common.Query{}
+	}
+	q.queries = append(q.queries, query)
+}
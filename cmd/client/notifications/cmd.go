@@ -18,8 +18,15 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"oxia/cmd/client/common"
+	"oxia/oxia"
 )
 
+var keyPrefixes []string
+
+func init() {
+	Cmd.Flags().StringSliceVar(&keyPrefixes, "key-prefix", []string{}, "Only follow the keys with the given prefixes")
+}
+
 var Cmd = &cobra.Command{
 	Use:   "notifications",
 	Short: "Get notifications stream",
@@ -36,7 +43,12 @@ func exec(cmd *cobra.Command, args []string) error {
 
 	defer client.Close()
 
-	notifications, err := client.GetNotifications()
+	var options []oxia.NotificationsOption
+	for _, prefix := range keyPrefixes {
+		options = append(options, oxia.FilterKeyPrefix(prefix))
+	}
+
+	notifications, err := client.GetNotifications(options...)
 	if err != nil {
 		return err
 	}
@@ -17,6 +17,7 @@ package flag
 import (
 	"fmt"
 	"github.com/spf13/cobra"
+	"oxia/common/security"
 	"oxia/kubernetes"
 )
 
@@ -31,3 +32,38 @@ func InternalAddr(cmd *cobra.Command, conf *string) {
 func MetricsAddr(cmd *cobra.Command, conf *string) {
 	cmd.Flags().StringVarP(conf, "metrics-addr", "m", fmt.Sprintf("0.0.0.0:%d", kubernetes.MetricsPort.Port), "Metrics service bind address")
 }
+
+// ServerTLS adds the flags to configure the TLS of a service, which are
+// prefixed with the name of the service
+func ServerTLS(cmd *cobra.Command, service string, conf *security.TLSOptions) {
+	cmd.Flags().StringVar(&conf.CertFile, service+"-tls-cert-file", "", fmt.Sprintf("Certificate of the %s service. TLS is enabled when set", service))
+	cmd.Flags().StringVar(&conf.KeyFile, service+"-tls-key-file", "", fmt.Sprintf("Private key of the %s service certificate", service))
+	cmd.Flags().StringVar(&conf.TrustedCaFile, service+"-tls-trusted-ca-file", "", fmt.Sprintf("CA used to verify the certificates of the %s service clients", service))
+	cmd.Flags().BoolVar(&conf.RequireClientCert, service+"-tls-require-client-cert", false, fmt.Sprintf("Reject the %s service clients without a certificate signed by the trusted CA", service))
+}
+
+// ClientTLS adds the flags to configure the TLS of the connections to a
+// service, which are prefixed with `prefix` and inherited by the sub-commands
+func ClientTLS(cmd *cobra.Command, prefix string, conf *security.TLSOptions) {
+	cmd.PersistentFlags().StringVar(&conf.CertFile, prefix+"tls-cert-file", "", "Client certificate presented to the servers")
+	cmd.PersistentFlags().StringVar(&conf.KeyFile, prefix+"tls-key-file", "", "Private key of the client certificate")
+	cmd.PersistentFlags().StringVar(&conf.TrustedCaFile, prefix+"tls-trusted-ca-file", "", "CA used to verify the servers. TLS is enabled when set")
+	cmd.PersistentFlags().StringVar(&conf.ServerName, prefix+"tls-server-name", "", "Name used to verify the servers, if different from their host")
+	cmd.PersistentFlags().BoolVar(&conf.InsecureSkipVerify, prefix+"tls-insecure-skip-verify", false, "Connect over TLS without verifying the servers")
+}
+
+// ServerAuth adds the flags to configure the authentication of the clients of
+// a service, which are prefixed with the name of the service
+func ServerAuth(cmd *cobra.Command, service string, conf *security.AuthenticationOptions) {
+	cmd.Flags().StringVar(&conf.Provider, service+"-auth-provider", "", fmt.Sprintf(`Authentication of the %s service clients, either "token" or "jwt". Disabled when empty`, service))
+	cmd.Flags().StringVar(&conf.TokenFile, service+"-auth-token-file", "", "File with one <token>,<principal> pair per line, for the token provider")
+	cmd.Flags().StringVar(&conf.JwksFile, service+"-auth-jwks-file", "", "JSON Web Key Set used to verify the tokens, for the jwt provider")
+	cmd.Flags().StringVar(&conf.JwtIssuer, service+"-auth-jwt-issuer", "", "Expected issuer of the tokens, for the jwt provider")
+	cmd.Flags().StringVar(&conf.JwtAudience, service+"-auth-jwt-audience", "", "Expected audience of the tokens, for the jwt provider")
+}
+
+// ClientAuth adds the flag to set the token presented to the servers, which is
+// inherited by the sub-commands
+func ClientAuth(cmd *cobra.Command, conf *string) {
+	cmd.PersistentFlags().StringVar(conf, "auth-token", "", "Bearer token presented to the servers")
+}
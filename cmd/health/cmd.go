@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"oxia/cmd/flag"
 	"oxia/common"
+	"oxia/common/security"
 	"oxia/kubernetes"
 	"time"
 )
@@ -30,6 +32,7 @@ type Config struct {
 	Port    int
 	Timeout time.Duration
 	Service string
+	TLS     security.TLSOptions
 }
 
 func NewConfig() Config {
@@ -57,12 +60,18 @@ func init() {
 	Cmd.Flags().IntVar(&config.Port, "port", config.Port, "Server port")
 	Cmd.Flags().DurationVar(&config.Timeout, "timeout", config.Timeout, "Health check timeout")
 	Cmd.Flags().StringVar(&config.Service, "service", config.Service, "Health check service")
+	flag.ClientTLS(Cmd, "", &config.TLS)
 	Cmd.SilenceUsage = true
 	Cmd.SilenceErrors = true
 }
 
 func exec(*cobra.Command, []string) error {
-	clientPool := common.NewClientPool()
+	tlsConf, err := config.TLS.MakeClientTLSConf()
+	if err != nil {
+		return err
+	}
+
+	clientPool := common.NewClientPool(tlsConf, nil)
 
 	serverAddress := fmt.Sprintf("%s:%d", config.Host, config.Port)
 
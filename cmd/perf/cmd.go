@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"io"
+	"oxia/cmd/flag"
 	"oxia/common"
 	"oxia/kubernetes"
 	"oxia/oxia"
@@ -49,6 +50,8 @@ func init() {
 	Cmd.Flags().DurationVar(&config.BatchLinger, "batch-linger", oxia.DefaultBatchLinger, "Batch linger time")
 	Cmd.Flags().IntVar(&config.MaxRequestsPerBatch, "max-requests-per-batch", oxia.DefaultMaxRequestsPerBatch, "Maximum requests per batch")
 	Cmd.Flags().DurationVar(&config.RequestTimeout, "request-timeout", oxia.DefaultRequestTimeout, "Request timeout")
+	flag.ClientTLS(Cmd, "", &config.TLS)
+	flag.ClientAuth(Cmd, &config.AuthToken)
 }
 
 func exec(*cobra.Command, []string) {
@@ -37,11 +37,15 @@ var (
 func init() {
 	flag.PublicAddr(Cmd, &conf.PublicServiceAddr)
 	flag.MetricsAddr(Cmd, &conf.MetricsServiceAddr)
+	flag.ServerTLS(Cmd, "public", &conf.PublicServerTLS)
+	flag.ServerAuth(Cmd, "public", &conf.PublicServerAuth)
+	Cmd.Flags().StringVar(&conf.PublicServerAuthorizationFile, "public-authorization-file", "", "YAML file with the roles that restrict the operations of the public service clients")
 	Cmd.Flags().Uint32VarP(&conf.NumShards, "shards", "s", 1, "Number of shards")
 	Cmd.Flags().StringVar(&conf.DataDir, "data-dir", "./data/db", "Directory where to store data")
 	Cmd.Flags().StringVar(&conf.WalDir, "wal-dir", "./data/wal", "Directory for write-ahead-logs")
 	Cmd.Flags().DurationVar(&conf.WalRetentionTime, "wal-retention-time", 1*time.Hour, "Retention time for the entries in the write-ahead-log")
 	Cmd.Flags().DurationVar(&conf.NotificationsRetentionTime, "notifications-retention-time", 1*time.Hour, "Retention time for the db notifications to clients")
+	Cmd.Flags().BoolVar(&conf.NotificationsIncludeValues, "notifications-include-values", false, "Store the values of the records in the db notifications, for the clients that request them")
 }
 
 func exec(*cobra.Command, []string) {
@@ -16,11 +16,13 @@ package common
 
 import (
 	"context"
+	"crypto/tls"
 	"github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/peer"
@@ -42,14 +44,21 @@ type ClientPool interface {
 
 type clientPool struct {
 	sync.RWMutex
-	connections map[string]grpc.ClientConnInterface
+	connections    map[string]grpc.ClientConnInterface
+	tlsConf        *tls.Config
+	authentication credentials.PerRPCCredentials
 
 	log zerolog.Logger
 }
 
-func NewClientPool() ClientPool {
+// NewClientPool creates a pool of connections to the given targets.
+// When `tlsConf` is nil, the connections are established in plaintext. When
+// `authentication` is set, its credentials are attached to all the calls
+func NewClientPool(tlsConf *tls.Config, authentication credentials.PerRPCCredentials) ClientPool {
 	return &clientPool{
-		connections: make(map[string]grpc.ClientConnInterface),
+		connections:    make(map[string]grpc.ClientConnInterface),
+		tlsConf:        tlsConf,
+		authentication: authentication,
 		log: log.With().
 			Str("component", "client-pool").
 			Logger(),
@@ -128,11 +137,21 @@ func (cp *clientPool) getConnection(target string) (grpc.ClientConnInterface, er
 		Str("server_address", target).
 		Msg("Creating new GRPC connection")
 
-	cnx, err := grpc.Dial(target,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	transportCredentials := insecure.NewCredentials()
+	if cp.tlsConf != nil {
+		transportCredentials = credentials.NewTLS(cp.tlsConf)
+	}
+
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCredentials),
 		grpc.WithStreamInterceptor(grpc_prometheus.StreamClientInterceptor),
 		grpc.WithUnaryInterceptor(grpc_prometheus.UnaryClientInterceptor),
-	)
+	}
+	if cp.authentication != nil {
+		options = append(options, grpc.WithPerRPCCredentials(cp.authentication))
+	}
+
+	cnx, err := grpc.Dial(target, options...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error connecting to %s", target)
 	}
@@ -15,10 +15,12 @@
 package container
 
 import (
+	"crypto/tls"
 	"github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"io"
 	"net"
 	"oxia/common"
@@ -35,7 +37,11 @@ type GrpcServer interface {
 }
 
 type GrpcProvider interface {
-	StartGrpcServer(name, bindAddress string, registerFunc func(grpc.ServiceRegistrar)) (GrpcServer, error)
+	// StartGrpcServer starts serving the services registered by `registerFunc`.
+	// When `tlsConf` is nil, the server listens in plaintext. The additional
+	// options, such as interceptors, are applied after the default ones
+	StartGrpcServer(name, bindAddress string, registerFunc func(grpc.ServiceRegistrar), tlsConf *tls.Config,
+		options ...grpc.ServerOption) (GrpcServer, error)
 }
 
 var Default = &defaultProvider{}
@@ -43,8 +49,9 @@ var Default = &defaultProvider{}
 type defaultProvider struct {
 }
 
-func (d *defaultProvider) StartGrpcServer(name, bindAddress string, registerFunc func(grpc.ServiceRegistrar)) (GrpcServer, error) {
-	return newDefaultGrpcProvider(name, bindAddress, registerFunc)
+func (d *defaultProvider) StartGrpcServer(name, bindAddress string, registerFunc func(grpc.ServiceRegistrar), tlsConf *tls.Config,
+	options ...grpc.ServerOption) (GrpcServer, error) {
+	return newDefaultGrpcProvider(name, bindAddress, registerFunc, tlsConf, options)
 }
 
 type defaultGrpcServer struct {
@@ -54,13 +61,20 @@ type defaultGrpcServer struct {
 	log    zerolog.Logger
 }
 
-func newDefaultGrpcProvider(name, bindAddress string, registerFunc func(grpc.ServiceRegistrar)) (GrpcServer, error) {
+func newDefaultGrpcProvider(name, bindAddress string, registerFunc func(grpc.ServiceRegistrar), tlsConf *tls.Config,
+	extraOptions []grpc.ServerOption) (GrpcServer, error) {
+	options := []grpc.ServerOption{
+		grpc.ChainStreamInterceptor(grpc_prometheus.StreamServerInterceptor),
+		grpc.ChainUnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
+		grpc.MaxRecvMsgSize(maxGrpcFrameSize),
+	}
+	if tlsConf != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConf)))
+	}
+	options = append(options, extraOptions...)
+
 	c := &defaultGrpcServer{
-		server: grpc.NewServer(
-			grpc.ChainStreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-			grpc.ChainUnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
-			grpc.MaxRecvMsgSize(maxGrpcFrameSize),
-		),
+		server: grpc.NewServer(options...),
 	}
 	registerFunc(c.server)
 	grpc_prometheus.Register(c.server)
@@ -75,6 +89,7 @@ func newDefaultGrpcProvider(name, bindAddress string, registerFunc func(grpc.Ser
 	c.log = log.With().
 		Str("grpc-server", name).
 		Str("bindAddress", listener.Addr().String()).
+		Bool("tls", tlsConf != nil).
 		Logger()
 
 	go common.DoWithLabels(map[string]string{
@@ -31,6 +31,8 @@ const (
 	CodeInvalidSession         codes.Code = 108
 	CodeInvalidSessionTimeout  codes.Code = 109
 	CodeNamespaceNotFound      codes.Code = 110
+	CodeInvalidSequentialKeys  codes.Code = 111
+	CodeNotificationsTrimmed   codes.Code = 112
 )
 
 var (
@@ -45,4 +47,6 @@ var (
 	ErrorInvalidSession         = status.Error(CodeInvalidSession, "oxia: session not found")
 	ErrorInvalidSessionTimeout  = status.Error(CodeInvalidSessionTimeout, "oxia: invalid session timeout")
 	ErrorNamespaceNotFound      = status.Error(CodeNamespaceNotFound, "oxia: namespace not found")
+	ErrorInvalidSequentialKeys  = status.Error(CodeInvalidSequentialKeys, "oxia: invalid sequence key deltas")
+	ErrorNotificationsTrimmed   = status.Error(CodeNotificationsTrimmed, "oxia: notifications offset was already trimmed")
 )
@@ -14,5 +14,102 @@
 
 package common
 
-// InternalKeyPrefix is the prefix of keys used by oxia.
-const InternalKeyPrefix = "__oxia/"
+import (
+	"bytes"
+	"strings"
+)
+
+const (
+	// InternalKeyPrefix is the prefix of keys used by oxia.
+	InternalKeyPrefix = "__oxia/"
+
+	// InternalKeysEnd sorts right after all the internal keys, since they share
+	// the same first segment. The internal keys are in the range
+	// [InternalKeyPrefix, InternalKeysEnd)
+	InternalKeysEnd = "__oxia\x00/"
+)
+
+// CompareWithSlash is the ordering of the keys in Oxia.
+//
+// Keys are compared one `/` separated segment at a time. When the segments are
+// equal, a key without further `/` comes before a key that has more of them, so
+// that all the direct children of a path are sorted before its grandchildren.
+func CompareWithSlash(a, b []byte) int {
+	for len(a) > 0 && len(b) > 0 {
+		idxA, idxB := bytes.IndexByte(a, '/'), bytes.IndexByte(b, '/')
+		if idxA < 0 && idxB < 0 {
+			return bytes.Compare(a, b)
+		} else if idxA < 0 && idxB >= 0 {
+			return -1
+		} else if idxA >= 0 && idxB < 0 {
+			return +1
+		}
+
+		// At this point, both slices have '/'
+		spanA, spanB := a[:idxA], b[:idxB]
+
+		spanRes := bytes.Compare(spanA, spanB)
+		if spanRes != 0 {
+			return spanRes
+		}
+
+		a, b = a[idxA+1:], b[idxB+1:]
+	}
+
+	if len(a) < len(b) {
+		return -1
+	} else if len(a) > len(b) {
+		return +1
+	} else {
+		return 0
+	}
+}
+
+// IsInternalKey returns whether the key is reserved for oxia
+func IsInternalKey(key string) bool {
+	return strings.HasPrefix(key, InternalKeyPrefix)
+}
+
+// KeyRange is the range of keys [Start, End)
+type KeyRange struct {
+	Start string
+	End   string
+}
+
+// UserKeyRanges splits the range [start, end) into the sub-ranges that do not
+// contain any internal key, in ascending order
+func UserKeyRanges(start, end string) []KeyRange {
+	ranges := make([]KeyRange, 0, 2)
+	if compareKeys(start, InternalKeyPrefix) < 0 {
+		ranges = append(ranges, KeyRange{start, minKey(end, InternalKeyPrefix)})
+	}
+	if compareKeys(end, InternalKeysEnd) > 0 {
+		ranges = append(ranges, KeyRange{maxKey(start, InternalKeysEnd), end})
+	}
+
+	nonEmpty := ranges[:0]
+	for _, r := range ranges {
+		if compareKeys(r.Start, r.End) < 0 {
+			nonEmpty = append(nonEmpty, r)
+		}
+	}
+	return nonEmpty
+}
+
+func compareKeys(a, b string) int {
+	return CompareWithSlash([]byte(a), []byte(b))
+}
+
+func minKey(a, b string) string {
+	if compareKeys(a, b) < 0 {
+		return a
+	}
+	return b
+}
+
+func maxKey(a, b string) string {
+	if compareKeys(a, b) > 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"bufio"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"os"
+	"strings"
+)
+
+const (
+	// MetadataAuthorization is the gRPC metadata that carries the credentials
+	// of the clients, as `Bearer <token>`
+	MetadataAuthorization = "authorization"
+	BearerPrefix          = "Bearer "
+
+	AuthenticationProviderNone  = ""
+	AuthenticationProviderToken = "token"
+	AuthenticationProviderJwt   = "jwt"
+)
+
+var (
+	ErrorInvalidToken                  = errors.New("auth: invalid token")
+	ErrorUnknownAuthenticationProvider = errors.New(`auth: the provider must be one of "token" or "jwt"`)
+)
+
+// AuthenticationOptions contains the configuration of the verification of the
+// tokens presented by the clients.
+type AuthenticationOptions struct {
+	// Provider is either "token", to check the tokens against TokenFile, or "jwt",
+	// to verify the JSON Web Tokens against the keys in JwksFile. When empty,
+	// the clients are not authenticated
+	Provider string
+	// TokenFile contains one `<token>,<principal>` pair per line. The empty lines
+	// and the lines starting with `#` are ignored
+	TokenFile string
+	// JwksFile contains the JSON Web Key Set used to verify the signature of the
+	// tokens. The principal is taken from the `sub` claim
+	JwksFile string
+	// JwtIssuer, when set, must match the `iss` claim of the tokens
+	JwtIssuer string
+	// JwtAudience, when set, must be included in the `aud` claim of the tokens
+	JwtAudience string
+}
+
+// Authenticator verifies the tokens presented by the clients.
+type Authenticator interface {
+	// Authenticate returns the principal identified by the token, or
+	// ErrorInvalidToken if the token is not valid
+	Authenticate(token string) (principal string, err error)
+}
+
+// NewAuthenticator creates the authenticator configured by the options.
+// It returns nil when no provider is set, for the clients not to be authenticated.
+func NewAuthenticator(options AuthenticationOptions) (Authenticator, error) {
+	switch options.Provider {
+	case AuthenticationProviderNone:
+		return nil, nil
+	case AuthenticationProviderToken:
+		return NewStaticTokenAuthenticator(options.TokenFile)
+	case AuthenticationProviderJwt:
+		return NewJwtAuthenticator(options.JwksFile, options.JwtIssuer, options.JwtAudience)
+	default:
+		return nil, ErrorUnknownAuthenticationProvider
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type staticTokenAuthenticator struct {
+	principals map[string]string
+}
+
+// NewStaticTokenAuthenticator creates an authenticator that accepts the tokens
+// listed in the file, with one `<token>,<principal>` pair per line.
+func NewStaticTokenAuthenticator(tokenFile string) (Authenticator, error) {
+	file, err := os.Open(tokenFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth: failed to open the token file")
+	}
+	defer file.Close()
+
+	a := &staticTokenAuthenticator{
+		principals: make(map[string]string),
+	}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		token, principal, found := strings.Cut(line, ",")
+		token = strings.TrimSpace(token)
+		principal = strings.TrimSpace(principal)
+		if !found || token == "" || principal == "" {
+			return nil, errors.Errorf("auth: invalid entry in the token file at line %d", lineNumber)
+		}
+		a.principals[token] = principal
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "auth: failed to read the token file")
+	}
+	return a, nil
+}
+
+func (a *staticTokenAuthenticator) Authenticate(token string) (string, error) {
+	if principal, ok := a.principals[token]; ok {
+		return principal, nil
+	}
+	return "", ErrorInvalidToken
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the principal of the authenticated client that
+// issued the call
+func PrincipalFromContext(ctx context.Context) (principal string, ok bool) {
+	principal, ok = ctx.Value(principalKey{}).(string)
+	return principal, ok
+}
+
+func withPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// AuthenticationInterceptor rejects the calls that don't carry a valid bearer
+// token and makes the principal of the others available through
+// [PrincipalFromContext].
+type AuthenticationInterceptor struct {
+	authenticator Authenticator
+	log           zerolog.Logger
+}
+
+func NewAuthenticationInterceptor(authenticator Authenticator) *AuthenticationInterceptor {
+	return &AuthenticationInterceptor{
+		authenticator: authenticator,
+		log: log.With().
+			Str("component", "authentication").
+			Logger(),
+	}
+}
+
+// ServerOptions returns the interceptors that authenticate all the unary and
+// streaming calls
+func (i *AuthenticationInterceptor) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(i.unaryInterceptor),
+		grpc.ChainStreamInterceptor(i.streamInterceptor),
+	}
+}
+
+func (i *AuthenticationInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MetadataAuthorization)
+	if len(values) == 0 || !strings.HasPrefix(values[0], BearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "oxia: missing bearer token")
+	}
+
+	principal, err := i.authenticator.Authenticate(strings.TrimPrefix(values[0], BearerPrefix))
+	if err != nil {
+		i.log.Warn().Err(err).
+			Str("method", method).
+			Msg("Rejected a call with an invalid token")
+		return nil, status.Error(codes.Unauthenticated, "oxia: invalid bearer token")
+	}
+	return withPrincipal(ctx, principal), nil
+}
+
+func (i *AuthenticationInterceptor) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	ctx, err := i.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i *AuthenticationInterceptor) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream carries the principal in the context of the stream
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
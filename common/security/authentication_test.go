@@ -0,0 +1,95 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTokenFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "tokens")
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	return file
+}
+
+func TestStaticTokenAuthenticator(t *testing.T) {
+	a, err := NewAuthenticator(AuthenticationOptions{
+		Provider:  AuthenticationProviderToken,
+		TokenFile: writeTokenFile(t, "# Comment\ntoken-a,service-a\n\n  token-b , service-b\n"),
+	})
+	assert.NoError(t, err)
+
+	principal, err := a.Authenticate("token-a")
+	assert.NoError(t, err)
+	assert.Equal(t, "service-a", principal)
+
+	principal, err = a.Authenticate("token-b")
+	assert.NoError(t, err)
+	assert.Equal(t, "service-b", principal)
+
+	_, err = a.Authenticate("token-c")
+	assert.ErrorIs(t, err, ErrorInvalidToken)
+
+	_, err = NewStaticTokenAuthenticator(writeTokenFile(t, "token-without-principal\n"))
+	assert.Error(t, err)
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	a, err := NewAuthenticator(AuthenticationOptions{})
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+
+	_, err = NewAuthenticator(AuthenticationOptions{Provider: "unknown"})
+	assert.ErrorIs(t, err, ErrorUnknownAuthenticationProvider)
+}
+
+func TestAuthenticationInterceptor(t *testing.T) {
+	a, err := NewStaticTokenAuthenticator(writeTokenFile(t, "token-a,service-a\n"))
+	assert.NoError(t, err)
+	interceptor := NewAuthenticationInterceptor(a)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/Write"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		principal, ok := PrincipalFromContext(ctx)
+		assert.True(t, ok)
+		return principal, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "Bearer token-a"))
+	res, err := interceptor.unaryInterceptor(ctx, nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "service-a", res)
+
+	for _, md := range []metadata.MD{
+		metadata.Pairs(MetadataAuthorization, "Bearer token-b"),
+		metadata.Pairs(MetadataAuthorization, "token-a"),
+		metadata.MD{},
+	} {
+		_, err = interceptor.unaryInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+
+	_, err = interceptor.unaryInterceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"os"
+	"oxia/common"
+	"strings"
+)
+
+// Operation is a kind of call of the public service that is subject to the
+// authorization rules
+type Operation string
+
+const (
+	// OperationRead covers the get and range scan requests
+	OperationRead Operation = "read"
+	// OperationWrite covers the put, delete and delete range requests
+	OperationWrite Operation = "write"
+	// OperationList covers the list and count requests
+	OperationList Operation = "list"
+	// OperationNotifications covers the notifications streams
+	OperationNotifications Operation = "notifications"
+	// OperationSession covers the creation, the keep-alive and the closing of
+	// the sessions
+	OperationSession Operation = "session"
+)
+
+var (
+	ErrorInvalidAuthorizationRule = errors.New("auth: invalid authorization rule")
+)
+
+// AuthorizationConfig contains the roles granted to the principals of the
+// authenticated clients. A call is allowed when any role of its principal has
+// a rule that covers it, and denied otherwise.
+type AuthorizationConfig struct {
+	Roles []AuthorizationRole `json:"roles" yaml:"roles"`
+}
+
+type AuthorizationRole struct {
+	Name string `json:"name" yaml:"name"`
+	// Principals are the principals that are granted the role
+	Principals []string `json:"principals" yaml:"principals"`
+	// Rules are the operations allowed by the role
+	Rules []AuthorizationRule `json:"rules" yaml:"rules"`
+}
+
+// AuthorizationRule allows the operations on the keys of a namespace that start
+// with the prefix. The prefix must either be empty, to cover the whole
+// namespace, or end with `/`, for the keys under a path to be contiguous in the
+// Oxia ordering.
+type AuthorizationRule struct {
+	Namespace  string      `json:"namespace" yaml:"namespace"`
+	Prefix     string      `json:"prefix" yaml:"prefix"`
+	Operations []Operation `json:"operations" yaml:"operations"`
+}
+
+// LoadAuthorizationConfig reads the roles from a YAML file
+func LoadAuthorizationConfig(file string) (*AuthorizationConfig, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth: failed to read the authorization file")
+	}
+
+	config := &AuthorizationConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrap(err, "auth: failed to parse the authorization file")
+	}
+	return config, nil
+}
+
+type permissionKey struct {
+	principal string
+	namespace string
+	operation Operation
+}
+
+// Authorizer gives the permissions of the principals, according to their roles
+type Authorizer struct {
+	permissions map[permissionKey]Permissions
+}
+
+func NewAuthorizer(config AuthorizationConfig) (*Authorizer, error) {
+	a := &Authorizer{
+		permissions: make(map[permissionKey]Permissions),
+	}
+
+	for _, role := range config.Roles {
+		for _, rule := range role.Rules {
+			if err := rule.validate(); err != nil {
+				return nil, errors.Wrapf(err, "role %q", role.Name)
+			}
+
+			for _, principal := range role.Principals {
+				for _, operation := range rule.Operations {
+					key := permissionKey{principal, rule.Namespace, operation}
+					a.permissions[key] = append(a.permissions[key], rule.Prefix)
+				}
+			}
+		}
+	}
+	return a, nil
+}
+
+func (r *AuthorizationRule) validate() error {
+	if r.Namespace == "" {
+		return errors.Wrap(ErrorInvalidAuthorizationRule, "the namespace must be set")
+	}
+	if r.Prefix != "" && !strings.HasSuffix(r.Prefix, "/") {
+		return errors.Wrapf(ErrorInvalidAuthorizationRule, "the prefix %q must end with '/'", r.Prefix)
+	}
+	for _, operation := range r.Operations {
+		switch operation {
+		case OperationRead, OperationWrite, OperationList, OperationNotifications, OperationSession:
+		default:
+			return errors.Wrapf(ErrorInvalidAuthorizationRule, "unknown operation %q", operation)
+		}
+	}
+	return nil
+}
+
+// Permissions returns the permissions of the principal for the operation on
+// the namespace
+func (a *Authorizer) Permissions(principal string, namespace string, operation Operation) Permissions {
+	return a.permissions[permissionKey{principal, namespace, operation}]
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Permissions are the key prefixes on which an operation is allowed
+type Permissions []string
+
+// AllowAll are the permissions that cover all the keys
+var AllowAll = Permissions{""}
+
+// IsEmpty returns whether the operation is denied on all the keys
+func (p Permissions) IsEmpty() bool {
+	return len(p) == 0
+}
+
+// AllowsKey returns whether the key is covered by the permissions
+func (p Permissions) AllowsKey(key string) bool {
+	for _, prefix := range p {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsPrefix returns whether all the keys starting with the prefix are
+// covered by the permissions
+func (p Permissions) AllowsPrefix(prefix string) bool {
+	return p.AllowsKey(prefix)
+}
+
+// AllowsRange returns whether all the keys in the range [start, end) are
+// covered by the permissions. The range must be within the keys of a single
+// prefix, since the ranges of the different prefixes are not merged.
+func (p Permissions) AllowsRange(start string, end string) bool {
+	if compareKeys(start, end) >= 0 {
+		// The range is empty
+		return true
+	}
+
+	for _, prefix := range p {
+		if prefix == "" {
+			return true
+		}
+		if compareKeys(start, prefix) >= 0 && compareKeys(end, prefixEnd(prefix)) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixEnd returns the key that sorts right after all the keys starting with
+// the prefix, which ends with `/`
+func prefixEnd(prefix string) string {
+	return prefix[:len(prefix)-1] + "\x00/"
+}
+
+func compareKeys(a, b string) int {
+	return common.CompareWithSlash([]byte(a), []byte(b))
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testAuthorizationConfig = `
+roles:
+  - name: billing-reader
+    principals: [service-a]
+    rules:
+      - namespace: payments
+        prefix: /billing/
+        operations: [read, list, notifications]
+  - name: billing-writer
+    principals: [service-b]
+    rules:
+      - namespace: payments
+        prefix: /billing/
+        operations: [read, write, list, notifications, session]
+      - namespace: payments
+        prefix: /audit/
+        operations: [write]
+  - name: admin
+    principals: [admin]
+    rules:
+      - namespace: default
+        operations: [read, write]
+`
+
+func TestAuthorizer(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "authorization.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(testAuthorizationConfig), 0600))
+
+	config, err := LoadAuthorizationConfig(file)
+	assert.NoError(t, err)
+	assert.Len(t, config.Roles, 3)
+
+	a, err := NewAuthorizer(*config)
+	assert.NoError(t, err)
+
+	p := a.Permissions("service-a", "payments", OperationRead)
+	assert.True(t, p.AllowsKey("/billing/invoice-1"))
+	assert.True(t, p.AllowsKey("/billing/eu/invoice-1"))
+	assert.False(t, p.AllowsKey("/billing"))
+	assert.False(t, p.AllowsKey("/audit/entry-1"))
+
+	assert.True(t, a.Permissions("service-a", "payments", OperationWrite).IsEmpty())
+	assert.True(t, a.Permissions("service-a", "default", OperationRead).IsEmpty())
+	assert.True(t, a.Permissions("unknown", "payments", OperationRead).IsEmpty())
+
+	p = a.Permissions("service-b", "payments", OperationWrite)
+	assert.True(t, p.AllowsKey("/billing/invoice-1"))
+	assert.True(t, p.AllowsKey("/audit/entry-1"))
+	assert.False(t, p.AllowsKey("/other"))
+
+	p = a.Permissions("admin", "default", OperationWrite)
+	assert.True(t, p.AllowsKey("/any/key"))
+	assert.True(t, p.AllowsRange("", "\xff/"))
+}
+
+func TestPermissions_AllowsRange(t *testing.T) {
+	p := Permissions{"/billing/"}
+
+	for _, test := range []struct {
+		start   string
+		end     string
+		allowed bool
+	}{
+		{"/billing/a", "/billing/z", true},
+		{"/billing/", "/billing\x00/", true},
+		{"/billing/eu/", "/billing/eu\x00/", true},
+		{"/billing/z", "/billing/a", true},
+		{"/billing/a", "/billing\x00/a", false},
+		{"/a", "/billing/z", false},
+		{"/billing/a", "/zzz/a", false},
+		{"/billing/a", "/z", true}, // Empty, since "/z" sorts before "/billing/a"
+		{"", "\xff/", false},
+	} {
+		assert.Equal(t, test.allowed, p.AllowsRange(test.start, test.end), "[%q, %q)", test.start, test.end)
+	}
+
+	assert.True(t, AllowAll.AllowsRange("", "\xff/"))
+	assert.False(t, Permissions{}.AllowsRange("/billing/a", "/billing/z"))
+}
+
+func TestNewAuthorizer_InvalidRules(t *testing.T) {
+	for _, rule := range []AuthorizationRule{
+		{Prefix: "/billing/", Operations: []Operation{OperationRead}},
+		{Namespace: "payments", Prefix: "/billing", Operations: []Operation{OperationRead}},
+		{Namespace: "payments", Prefix: "/billing/", Operations: []Operation{"delete"}},
+	} {
+		_, err := NewAuthorizer(AuthorizationConfig{Roles: []AuthorizationRole{{
+			Name:       "role",
+			Principals: []string{"service"},
+			Rules:      []AuthorizationRule{rule},
+		}}})
+		assert.ErrorIs(t, err, ErrorInvalidAuthorizationRule)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// jsonWebKey is a public key of a JSON Web Key Set, as defined by RFC 7517
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC and OKP keys
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *int64          `json:"exp"`
+	NotBefore *int64          `json:"nbf"`
+}
+
+type verificationKey struct {
+	kid string
+	alg string
+	key crypto.PublicKey
+}
+
+type jwtAuthenticator struct {
+	keys     []verificationKey
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewJwtAuthenticator creates an authenticator that verifies the signature of
+// JSON Web Tokens against the keys in the JWKS file, and returns their subject
+// as principal. The RSA, ECDSA and Ed25519 signatures are supported, and the
+// tokens must have an expiration.
+func NewJwtAuthenticator(jwksFile string, issuer string, audience string) (Authenticator, error) {
+	data, err := os.ReadFile(jwksFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "auth: failed to read the JWKS file")
+	}
+
+	jwks := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, errors.Wrap(err, "auth: failed to parse the JWKS file")
+	}
+
+	a := &jwtAuthenticator{
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
+	}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "auth: invalid key %q in the JWKS file", jwk.Kid)
+		}
+		a.keys = append(a.keys, verificationKey{kid: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+
+	if len(a.keys) == 0 {
+		return nil, errors.New("auth: no signature key found in the JWKS file")
+	}
+	return a, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.Wrap(ErrorInvalidToken, "malformed token")
+	}
+
+	header := jwtHeader{}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.Wrap(ErrorInvalidToken, "malformed signature")
+	}
+
+	if !a.verifySignature(header, parts[0]+"."+parts[1], signature) {
+		return "", errors.Wrap(ErrorInvalidToken, "invalid signature")
+	}
+
+	claims := jwtClaims{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", err
+	}
+	if err := a.validateClaims(claims); err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+func (a *jwtAuthenticator) verifySignature(header jwtHeader, signed string, signature []byte) bool {
+	for _, k := range a.keys {
+		if header.Kid != "" && k.kid != "" && header.Kid != k.kid {
+			continue
+		}
+		if k.alg != "" && k.alg != header.Alg {
+			continue
+		}
+		if verify(header.Alg, k.key, []byte(signed), signature) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *jwtAuthenticator) validateClaims(claims jwtClaims) error {
+	now := a.now().Unix()
+	if claims.ExpiresAt == nil {
+		// A token without expiration could never be revoked
+		return errors.Wrap(ErrorInvalidToken, "missing expiration")
+	}
+	if now >= *claims.ExpiresAt {
+		return errors.Wrap(ErrorInvalidToken, "token expired")
+	}
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return errors.Wrap(ErrorInvalidToken, "token not valid yet")
+	}
+	if claims.Subject == "" {
+		return errors.Wrap(ErrorInvalidToken, "missing subject")
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return errors.Wrap(ErrorInvalidToken, "unexpected issuer")
+	}
+	if a.audience != "" && !containsAudience(claims.Audience, a.audience) {
+		return errors.Wrap(ErrorInvalidToken, "unexpected audience")
+	}
+	return nil
+}
+
+// containsAudience checks the `aud` claim, which can be either a single string
+// or an array of strings
+func containsAudience(raw json.RawMessage, audience string) bool {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single == audience
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(raw, &multiple); err == nil {
+		for _, a := range multiple {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.Wrap(ErrorInvalidToken, "malformed segment")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Wrap(ErrorInvalidToken, "malformed segment")
+	}
+	return nil
+}
+
+// verify checks the signature with the algorithm of the token. The `none` and
+// the HMAC algorithms are never accepted, since the keys are public.
+func verify(alg string, key crypto.PublicKey, signed []byte, signature []byte) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		var hash crypto.Hash
+		switch alg {
+		case "RS256", "PS256":
+			hash = crypto.SHA256
+		case "RS384", "PS384":
+			hash = crypto.SHA384
+		case "RS512", "PS512":
+			hash = crypto.SHA512
+		default:
+			return false
+		}
+		digest := hashOf(hash, signed)
+		if strings.HasPrefix(alg, "PS") {
+			return rsa.VerifyPSS(k, hash, digest, signature, nil) == nil
+		}
+		return rsa.VerifyPKCS1v15(k, hash, digest, signature) == nil
+
+	case *ecdsa.PublicKey:
+		var hash crypto.Hash
+		switch {
+		case alg == "ES256" && k.Curve == elliptic.P256():
+			hash = crypto.SHA256
+		case alg == "ES384" && k.Curve == elliptic.P384():
+			hash = crypto.SHA384
+		case alg == "ES512" && k.Curve == elliptic.P521():
+			hash = crypto.SHA512
+		default:
+			return false
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(k, hashOf(hash, signed), r, s)
+
+	case ed25519.PublicKey:
+		return alg == "EdDSA" && ed25519.Verify(k, signed, signature)
+
+	default:
+		return false
+	}
+}
+
+func hashOf(hash crypto.Hash, data []byte) []byte {
+	h := hash.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("the point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, errors.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, errors.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	assert.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signJwt creates a token signed with the key, using the algorithm that
+// corresponds to its type
+func signJwt(t *testing.T, key crypto.Signer, kid string, claims map[string]any) string {
+	t.Helper()
+	var alg string
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		alg = "RS256"
+	case *ecdsa.PrivateKey:
+		alg = "ES256"
+	case ed25519.PrivateKey:
+		alg = "EdDSA"
+	}
+
+	signed := encodeSegment(t, map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + encodeSegment(t, claims)
+
+	var signature []byte
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, hashOf(crypto.SHA256, []byte(signed)))
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, hashOf(crypto.SHA256, []byte(signed)))
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(k, []byte(signed))
+	}
+	assert.NoError(t, err)
+	return signed + "." + b64(signature)
+}
+
+func writeJwks(t *testing.T, keys ...map[string]string) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	assert.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(file, data, 0600))
+	return file
+}
+
+func TestJwtAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	jwksFile := writeJwks(t,
+		map[string]string{"kty": "RSA", "kid": "rsa", "n": b64(rsaKey.N.Bytes()),
+			"e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		map[string]string{"kty": "EC", "kid": "ec", "crv": "P-256",
+			"x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+		map[string]string{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": b64(edPublicKey)},
+	)
+
+	a, err := NewJwtAuthenticator(jwksFile, "oxia-issuer", "oxia")
+	assert.NoError(t, err)
+	now := time.Unix(1_700_000_000, 0)
+	a.(*jwtAuthenticator).now = func() time.Time { return now }
+
+	claims := func(sub string) map[string]any {
+		return map[string]any{
+			"sub": sub,
+			"iss": "oxia-issuer",
+			"aud": []string{"other", "oxia"},
+			"exp": now.Add(time.Hour).Unix(),
+		}
+	}
+
+	for kid, key := range map[string]crypto.Signer{"rsa": rsaKey, "ec": ecKey, "ed": edKey} {
+		principal, err := a.Authenticate(signJwt(t, key, kid, claims("service-"+kid)))
+		assert.NoError(t, err, kid)
+		assert.Equal(t, "service-"+kid, principal)
+	}
+
+	// The key must match the kid of the token
+	_, err = a.Authenticate(signJwt(t, rsaKey, "ec", claims("service")))
+	assert.ErrorIs(t, err, ErrorInvalidToken)
+
+	// A key that is not in the JWKS is rejected
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, err = a.Authenticate(signJwt(t, otherKey, "", claims("service")))
+	assert.ErrorIs(t, err, ErrorInvalidToken)
+
+	for name, mutate := range map[string]func(map[string]any){
+		"expired":       func(c map[string]any) { c["exp"] = now.Unix() },
+		"no-expiration": func(c map[string]any) { delete(c, "exp") },
+		"not-yet-valid": func(c map[string]any) { c["nbf"] = now.Add(time.Minute).Unix() },
+		"issuer":        func(c map[string]any) { c["iss"] = "other-issuer" },
+		"audience":      func(c map[string]any) { c["aud"] = "other" },
+		"no-subject":    func(c map[string]any) { delete(c, "sub") },
+		"empty-subject": func(c map[string]any) { c["sub"] = "" },
+	} {
+		c := claims("service")
+		mutate(c)
+		_, err = a.Authenticate(signJwt(t, ecKey, "ec", c))
+		assert.ErrorIs(t, err, ErrorInvalidToken, name)
+	}
+
+	// Unsigned tokens are never accepted
+	unsigned := encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, claims("service")) + "."
+	_, err = a.Authenticate(unsigned)
+	assert.ErrorIs(t, err, ErrorInvalidToken)
+
+	_, err = a.Authenticate("not-a-token")
+	assert.ErrorIs(t, err, ErrorInvalidToken)
+}
+
+func TestJwtAuthenticator_InvalidJwks(t *testing.T) {
+	_, err := NewJwtAuthenticator(writeJwks(t), "", "")
+	assert.Error(t, err)
+
+	_, err = NewJwtAuthenticator(writeJwks(t, map[string]string{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}), "", "")
+	assert.Error(t, err)
+
+	_, err = NewJwtAuthenticator(filepath.Join(t.TempDir(), "missing.json"), "", "")
+	assert.Error(t, err)
+
+	// The encryption keys are ignored
+	_, err = NewJwtAuthenticator(writeJwks(t, map[string]string{"kty": "oct", "use": "enc"}), "", "")
+	assert.EqualError(t, err, "auth: no signature key found in the JWKS file")
+}
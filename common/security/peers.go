@@ -0,0 +1,137 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"crypto/x509"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// PeerIdentities returns the identities carried by a certificate: its common
+// name, and its DNS and URI subject alternative names
+func PeerIdentities(cert *x509.Certificate) []string {
+	var identities []string
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	identities = append(identities, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+// healthServicePrefix is the prefix of the methods of the health service
+var healthServicePrefix = "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/"
+
+// PeerVerifier only lets through the calls from the peers that presented a
+// verified client certificate and, when the allow-list is not empty, that have
+// at least one identity in it.
+//
+// The calls to the health service are always let through, for the liveness and
+// readiness probes to check the service without a client certificate, since
+// they don't expose nor change any state.
+type PeerVerifier struct {
+	allowedPeers map[string]bool
+	log          zerolog.Logger
+}
+
+func NewPeerVerifier(allowedPeers []string) *PeerVerifier {
+	v := &PeerVerifier{
+		allowedPeers: make(map[string]bool),
+		log: log.With().
+			Str("component", "peer-verifier").
+			Logger(),
+	}
+	for _, p := range allowedPeers {
+		v.allowedPeers[p] = true
+	}
+	return v
+}
+
+// ServerOptions returns the interceptors that verify the peers of all the
+// unary and streaming calls
+func (v *PeerVerifier) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(v.unaryInterceptor),
+		grpc.ChainStreamInterceptor(v.streamInterceptor),
+	}
+}
+
+// Verify returns an `Unauthenticated` status if the peer of the call did not
+// present a verified certificate, and a `PermissionDenied` status if none of its
+// identities is allowed
+func (v *PeerVerifier) Verify(ctx context.Context, method string) error {
+	if strings.HasPrefix(method, healthServicePrefix) {
+		return nil
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "oxia: unknown peer")
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		v.log.Warn().
+			Str("peer", p.Addr.String()).
+			Str("method", method).
+			Msg("Rejected a call without a verified client certificate")
+		return status.Error(codes.Unauthenticated, "oxia: a verified client certificate is required")
+	}
+
+	if len(v.allowedPeers) == 0 {
+		return nil
+	}
+
+	identities := PeerIdentities(tlsInfo.State.VerifiedChains[0][0])
+	for _, identity := range identities {
+		if v.allowedPeers[identity] {
+			return nil
+		}
+	}
+
+	v.log.Warn().
+		Str("peer", p.Addr.String()).
+		Strs("identities", identities).
+		Str("method", method).
+		Msg("Rejected a call from a peer that is not allowed")
+	return status.Error(codes.PermissionDenied, "oxia: the peer is not allowed")
+}
+
+func (v *PeerVerifier) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	if err := v.Verify(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (v *PeerVerifier) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := v.Verify(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
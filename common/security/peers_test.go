@@ -0,0 +1,80 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/url"
+	"testing"
+)
+
+func peerContext(cert *x509.Certificate) context.Context {
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234},
+	}
+	if cert != nil {
+		p.AuthInfo = credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{cert}},
+			},
+		}
+	}
+	return peer.NewContext(context.Background(), p)
+}
+
+func TestPeerIdentities(t *testing.T) {
+	uri, _ := url.Parse("spiffe://oxia/coordinator")
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "coordinator"},
+		DNSNames: []string{"coordinator.oxia.svc"},
+		URIs:     []*url.URL{uri},
+	}
+
+	assert.Equal(t, []string{"coordinator", "coordinator.oxia.svc", "spiffe://oxia/coordinator"}, PeerIdentities(cert))
+	assert.Nil(t, PeerIdentities(&x509.Certificate{}))
+}
+
+func TestPeerVerifier(t *testing.T) {
+	coordinator := &x509.Certificate{Subject: pkix.Name{CommonName: "coordinator"}}
+	server := &x509.Certificate{DNSNames: []string{"server-0.oxia.svc"}}
+	unknown := &x509.Certificate{Subject: pkix.Name{CommonName: "client"}}
+
+	v := NewPeerVerifier([]string{"coordinator", "server-0.oxia.svc"})
+
+	assert.NoError(t, v.Verify(peerContext(coordinator), "/NewTerm"))
+	assert.NoError(t, v.Verify(peerContext(server), "/Replicate"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(v.Verify(peerContext(unknown), "/DeleteShard")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(v.Verify(peerContext(nil), "/Truncate")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(v.Verify(context.Background(), "/Truncate")))
+
+	// The health service is exempted
+	assert.NoError(t, v.Verify(peerContext(nil), "/grpc.health.v1.Health/Check"))
+	assert.NoError(t, v.Verify(peerContext(unknown), "/grpc.health.v1.Health/Watch"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(v.Verify(peerContext(nil), "/grpc.health.v1.HealthCheck")))
+
+	// Without allow-list, any verified certificate is accepted
+	v = NewPeerVerifier(nil)
+	assert.NoError(t, v.Verify(peerContext(unknown), "/DeleteShard"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(v.Verify(peerContext(nil), "/Truncate")))
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// How frequently the files are checked for changes, at most. The check is
+	// done when a connection is established
+	reloadCheckInterval = 10 * time.Second
+)
+
+var (
+	ErrorMissingCertificate = errors.New("tls: the certificate and the key files must be set")
+	ErrorInvalidTrustedCa   = errors.New("tls: no valid certificate found in the trusted CA file")
+	ErrorMissingTrustedCa   = errors.New("tls: the trusted CA file must be set to require client certificates")
+)
+
+// TLSOptions contains the configuration of the TLS connections, on either the
+// server or the client side.
+//
+// The certificate and the key are reloaded when their files change, so that
+// they can be rotated without restarting the process. The trusted CA is
+// reloaded as well by the servers, while the clients keep the one loaded when
+// their config is created.
+type TLSOptions struct {
+	// CertFile is the PEM-encoded certificate that is presented to the peers
+	CertFile string
+	// KeyFile is the PEM-encoded private key of the certificate
+	KeyFile string
+	// TrustedCaFile contains the PEM-encoded CA certificates used to verify the
+	// peers. When empty, the clients verify the servers against the system roots
+	TrustedCaFile string
+	// ServerName is used by the clients to verify the certificate of the servers,
+	// when it differs from the host in their address
+	ServerName string
+	// InsecureSkipVerify disables the verification of the servers by the clients.
+	// It must only be used for testing
+	InsecureSkipVerify bool
+	// RequireClientCert makes the servers reject the clients that don't present
+	// a certificate signed by the trusted CA
+	RequireClientCert bool
+}
+
+// IsEnabled returns whether any of the TLS options was set
+func (o *TLSOptions) IsEnabled() bool {
+	return o.CertFile != "" || o.KeyFile != "" || o.TrustedCaFile != "" || o.InsecureSkipVerify
+}
+
+// MakeServerTLSConf creates the configuration for a server, which presents the
+// certificate and, when a trusted CA is set, verifies the certificates of the
+// clients that provide one, or of all the clients with RequireClientCert.
+// It returns nil when no option is set, for the server to listen in plaintext.
+func (o *TLSOptions) MakeServerTLSConf() (*tls.Config, error) {
+	if !o.IsEnabled() {
+		return nil, nil
+	}
+	if o.CertFile == "" || o.KeyFile == "" {
+		return nil, ErrorMissingCertificate
+	}
+	if o.RequireClientCert && o.TrustedCaFile == "" {
+		return nil, ErrorMissingTrustedCa
+	}
+
+	r, err := newFileReloader(o.CertFile, o.KeyFile, o.TrustedCaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			certificate, trustedCAs := r.get()
+			conf := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2"},
+				Certificates: []tls.Certificate{*certificate},
+			}
+			if trustedCAs != nil {
+				conf.ClientCAs = trustedCAs
+				conf.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+			if o.RequireClientCert {
+				conf.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return conf, nil
+		},
+	}, nil
+}
+
+// MakeClientTLSConf creates the configuration for a client, which verifies the
+// servers and, when a certificate is set, presents it to them.
+// It returns nil when no option is set, for the client to connect in plaintext.
+func (o *TLSOptions) MakeClientTLSConf() (*tls.Config, error) {
+	if !o.IsEnabled() {
+		return nil, nil
+	}
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return nil, ErrorMissingCertificate
+	}
+
+	r, err := newFileReloader(o.CertFile, o.KeyFile, o.TrustedCaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CertFile != "" {
+		conf.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certificate, _ := r.get()
+			return certificate, nil
+		}
+	}
+
+	// The servers are verified by the standard verification, which checks that
+	// their certificate is valid for the name or the IP address they are dialed
+	// with. It uses the trusted CAs loaded when the config is created, since
+	// they cannot be changed afterwards.
+	_, conf.RootCAs = r.get()
+	return conf, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// fileReloader holds the certificate and the trusted CAs, and loads them again
+// when the modification time of any of their files changes
+type fileReloader struct {
+	sync.Mutex
+
+	certFile string
+	keyFile  string
+	caFile   string
+
+	lastCheck   time.Time
+	modTimes    map[string]time.Time
+	certificate *tls.Certificate
+	trustedCAs  *x509.CertPool
+	log         zerolog.Logger
+}
+
+func newFileReloader(certFile, keyFile, caFile string) (*fileReloader, error) {
+	r := &fileReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		log: log.With().
+			Str("component", "tls-reloader").
+			Str("cert-file", certFile).
+			Str("trusted-ca-file", caFile).
+			Logger(),
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.lastCheck = time.Now()
+	return r, nil
+}
+
+func (r *fileReloader) get() (*tls.Certificate, *x509.CertPool) {
+	r.Lock()
+	defer r.Unlock()
+
+	if time.Since(r.lastCheck) >= reloadCheckInterval {
+		r.lastCheck = time.Now()
+		if r.changed() {
+			if err := r.load(); err != nil {
+				r.log.Warn().Err(err).
+					Msg("Failed to reload the TLS files, keeping the previous ones")
+			} else {
+				r.log.Info().Msg("Reloaded the TLS files")
+			}
+		}
+	}
+
+	return r.certificate, r.trustedCAs
+}
+
+func (r *fileReloader) files() []string {
+	var files []string
+	for _, f := range []string{r.certFile, r.keyFile, r.caFile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+func (r *fileReloader) changed() bool {
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil || !info.ModTime().Equal(r.modTimes[f]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fileReloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			return errors.Wrap(err, "tls: failed to access file")
+		}
+		modTimes[f] = info.ModTime()
+	}
+
+	var certificate *tls.Certificate
+	if r.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return errors.Wrap(err, "tls: failed to load the certificate")
+		}
+		certificate = &cert
+	}
+
+	var trustedCAs *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return errors.Wrap(err, "tls: failed to read the trusted CA file")
+		}
+		trustedCAs = x509.NewCertPool()
+		if !trustedCAs.AppendCertsFromPEM(pem) {
+			return ErrorInvalidTrustedCa
+		}
+	}
+
+	r.modTimes = modTimes
+	r.certificate = certificate
+	r.trustedCAs = trustedCAs
+	return nil
+}
@@ -0,0 +1,286 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCa struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCa(t *testing.T, name string) *testCa {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return &testCa{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue writes a certificate signed by the CA, valid for `localhost`, and
+// returns the paths of the certificate and key files
+func (ca *testCa) issue(t *testing.T, dir string, name string) (certFile string, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func handshake(serverConf *tls.Config, clientConf *tls.Config) (*x509.Certificate, error) {
+	serverCnx, clientCnx := net.Pipe()
+	defer serverCnx.Close()
+	defer clientCnx.Close()
+
+	server := tls.Server(serverCnx, serverConf)
+	go func() {
+		_ = server.Handshake()
+	}()
+
+	client := tls.Client(clientCnx, clientConf)
+	if err := client.Handshake(); err != nil {
+		return nil, err
+	}
+	return client.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestTLSOptions_Handshake(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCa(t, "ca")
+	caFile := filepath.Join(dir, "ca.crt")
+	assert.NoError(t, os.WriteFile(caFile, ca.pem, 0600))
+	certFile, keyFile := ca.issue(t, dir, "server")
+
+	server := &TLSOptions{CertFile: certFile, KeyFile: keyFile}
+	serverConf, err := server.MakeServerTLSConf()
+	assert.NoError(t, err)
+
+	client := &TLSOptions{TrustedCaFile: caFile, ServerName: "localhost"}
+	clientConf, err := client.MakeClientTLSConf()
+	assert.NoError(t, err)
+
+	cert, err := handshake(serverConf, clientConf)
+	assert.NoError(t, err)
+	assert.Equal(t, "server", cert.Subject.CommonName)
+
+	// A server signed by a different CA is rejected
+	otherCa := newTestCa(t, "other-ca")
+	otherCertFile, otherKeyFile := otherCa.issue(t, dir, "other")
+	otherConf, err := (&TLSOptions{CertFile: otherCertFile, KeyFile: otherKeyFile}).MakeServerTLSConf()
+	assert.NoError(t, err)
+
+	_, err = handshake(otherConf, clientConf)
+	assert.Error(t, err)
+}
+
+func TestTLSOptions_ServerAddress(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCa(t, "ca")
+	caFile := filepath.Join(dir, "ca.crt")
+	assert.NoError(t, os.WriteFile(caFile, ca.pem, 0600))
+	certFile, keyFile := ca.issue(t, dir, "server")
+
+	serverConf, err := (&TLSOptions{CertFile: certFile, KeyFile: keyFile}).MakeServerTLSConf()
+	assert.NoError(t, err)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConf)
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			cnx, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = cnx.(*tls.Conn).Handshake()
+			_ = cnx.Close()
+		}
+	}()
+
+	// The certificate is only valid for `localhost`, and is rejected when the
+	// server is dialed by its IP address
+	clientConf, err := (&TLSOptions{TrustedCaFile: caFile}).MakeClientTLSConf()
+	assert.NoError(t, err)
+	_, err = tls.Dial("tcp", listener.Addr().String(), clientConf)
+	assert.Error(t, err)
+
+	// Unless the expected name is set
+	clientConf, err = (&TLSOptions{TrustedCaFile: caFile, ServerName: "localhost"}).MakeClientTLSConf()
+	assert.NoError(t, err)
+	cnx, err := tls.Dial("tcp", listener.Addr().String(), clientConf)
+	assert.NoError(t, err)
+	if cnx != nil {
+		_ = cnx.Close()
+	}
+}
+
+func TestTLSOptions_Reload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCa(t, "ca")
+	caFile := filepath.Join(dir, "ca.crt")
+	assert.NoError(t, os.WriteFile(caFile, ca.pem, 0600))
+	certFile, keyFile := ca.issue(t, dir, "server")
+
+	r, err := newFileReloader(certFile, keyFile, caFile)
+	assert.NoError(t, err)
+	first, _ := r.get()
+
+	// Replace the certificate and force the next check
+	newCertFile, newKeyFile := ca.issue(t, dir, "server-2")
+	assert.NoError(t, os.Rename(newCertFile, certFile))
+	assert.NoError(t, os.Rename(newKeyFile, keyFile))
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(certFile, future, future))
+	r.lastCheck = time.Time{}
+
+	second, _ := r.get()
+	assert.NotEqual(t, first.Certificate[0], second.Certificate[0])
+
+	// An invalid file is ignored, and the previous certificate is kept
+	assert.NoError(t, os.WriteFile(certFile, []byte("invalid"), 0600))
+	future = future.Add(time.Minute)
+	assert.NoError(t, os.Chtimes(certFile, future, future))
+	r.lastCheck = time.Time{}
+
+	third, _ := r.get()
+	assert.Equal(t, second.Certificate[0], third.Certificate[0])
+}
+
+func TestTLSOptions_Invalid(t *testing.T) {
+	conf, err := (&TLSOptions{}).MakeServerTLSConf()
+	assert.NoError(t, err)
+	assert.Nil(t, conf)
+
+	conf, err = (&TLSOptions{}).MakeClientTLSConf()
+	assert.NoError(t, err)
+	assert.Nil(t, conf)
+
+	_, err = (&TLSOptions{TrustedCaFile: "ca.pem"}).MakeServerTLSConf()
+	assert.ErrorIs(t, err, ErrorMissingCertificate)
+
+	_, err = (&TLSOptions{CertFile: "cert.pem"}).MakeClientTLSConf()
+	assert.ErrorIs(t, err, ErrorMissingCertificate)
+
+	_, err = (&TLSOptions{TrustedCaFile: "/non-existing-file"}).MakeClientTLSConf()
+	assert.Error(t, err)
+}
+
+func TestTLSOptions_RequireClientCert(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCa(t, "ca")
+	caFile := filepath.Join(dir, "ca.crt")
+	assert.NoError(t, os.WriteFile(caFile, ca.pem, 0600))
+	serverCertFile, serverKeyFile := ca.issue(t, dir, "server")
+	clientCertFile, clientKeyFile := ca.issue(t, dir, "client")
+
+	_, err := (&TLSOptions{CertFile: serverCertFile, KeyFile: serverKeyFile, RequireClientCert: true}).MakeServerTLSConf()
+	assert.ErrorIs(t, err, ErrorMissingTrustedCa)
+
+	serverConf, err := (&TLSOptions{
+		CertFile:          serverCertFile,
+		KeyFile:           serverKeyFile,
+		TrustedCaFile:     caFile,
+		RequireClientCert: true,
+	}).MakeServerTLSConf()
+	assert.NoError(t, err)
+
+	// The client without certificate is rejected by the server, which is
+	// detected on the first read
+	anonymousConf, err := (&TLSOptions{TrustedCaFile: caFile, ServerName: "localhost"}).MakeClientTLSConf()
+	assert.NoError(t, err)
+	assert.Error(t, handshakeAndRead(serverConf, anonymousConf))
+
+	clientConf, err := (&TLSOptions{
+		CertFile:      clientCertFile,
+		KeyFile:       clientKeyFile,
+		TrustedCaFile: caFile,
+		ServerName:    "localhost",
+	}).MakeClientTLSConf()
+	assert.NoError(t, err)
+	assert.NoError(t, handshakeAndRead(serverConf, clientConf))
+}
+
+func handshakeAndRead(serverConf *tls.Config, clientConf *tls.Config) error {
+	serverCnx, clientCnx := net.Pipe()
+	defer serverCnx.Close()
+	defer clientCnx.Close()
+
+	go func() {
+		server := tls.Server(serverCnx, serverConf)
+		if server.Handshake() == nil {
+			_, _ = server.Write([]byte{1})
+		}
+		_ = server.Close()
+	}()
+
+	client := tls.Client(clientCnx, clientConf)
+	if err := client.Handshake(); err != nil {
+		return err
+	}
+	_, err := client.Read(make([]byte, 1))
+	return err
+}
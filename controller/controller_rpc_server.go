@@ -34,7 +34,7 @@ func NewControllerRpcServer(bindAddress string) (*ControllerRpcServer, error) {
 	var err error
 	server.container, err = container.Default.StartGrpcServer("controller", bindAddress, func(registrar grpc.ServiceRegistrar) {
 		grpc_health_v1.RegisterHealthServer(registrar, server.healthServer)
-	})
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -21,6 +21,7 @@ import (
 	"go.uber.org/multierr"
 	"oxia/common"
 	"oxia/common/metrics"
+	"oxia/common/security"
 	"oxia/coordinator/impl"
 	"oxia/coordinator/model"
 	"oxia/kubernetes"
@@ -36,6 +37,12 @@ type Config struct {
 	FileMetadataPath         string
 	ClusterConfigProvider    func() (model.ClusterConfig, error)
 	ClusterConfigRefreshTime time.Duration
+
+	// InternalServerTLS configures the TLS of the coordinator service
+	InternalServerTLS security.TLSOptions
+	// PeerTLS configures the TLS of the connections to the internal service
+	// of the servers
+	PeerTLS security.TLSOptions
 }
 
 type MetadataProviderImpl string
@@ -84,8 +91,18 @@ func New(config Config) (*Coordinator, error) {
 		Interface("config", config).
 		Msg("Starting Oxia coordinator")
 
+	peerTLSConf, err := config.PeerTLS.MakeClientTLSConf()
+	if err != nil {
+		return nil, err
+	}
+
+	internalServerTLSConf, err := config.InternalServerTLS.MakeServerTLSConf()
+	if err != nil {
+		return nil, err
+	}
+
 	s := &Coordinator{
-		clientPool: common.NewClientPool(),
+		clientPool: common.NewClientPool(peerTLSConf, nil),
 	}
 
 	var metadataProvider impl.MetadataProvider
@@ -102,12 +119,11 @@ func New(config Config) (*Coordinator, error) {
 
 	rpcClient := impl.NewRpcProvider(s.clientPool)
 
-	var err error
 	if s.coordinator, err = impl.NewCoordinator(metadataProvider, config.ClusterConfigProvider, config.ClusterConfigRefreshTime, rpcClient); err != nil {
 		return nil, err
 	}
 
-	if s.rpcServer, err = newRpcServer(config.InternalServiceAddr); err != nil {
+	if s.rpcServer, err = newRpcServer(config.InternalServiceAddr, internalServerTLSConf); err != nil {
 		return nil, err
 	}
 
@@ -15,6 +15,7 @@
 package coordinator
 
 import (
+	"crypto/tls"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -26,7 +27,7 @@ type rpcServer struct {
 	healthServer *health.Server
 }
 
-func newRpcServer(bindAddress string) (*rpcServer, error) {
+func newRpcServer(bindAddress string, tlsConf *tls.Config) (*rpcServer, error) {
 	server := &rpcServer{
 		healthServer: health.NewServer(),
 	}
@@ -34,7 +35,7 @@ func newRpcServer(bindAddress string) (*rpcServer, error) {
 	var err error
 	server.grpcServer, err = container.Default.StartGrpcServer("coordinator", bindAddress, func(registrar grpc.ServiceRegistrar) {
 		grpc_health_v1.RegisterHealthServer(registrar, server.healthServer)
-	})
+	}, tlsConf)
 	if err != nil {
 		return nil, err
 	}
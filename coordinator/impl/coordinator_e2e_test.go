@@ -64,7 +64,7 @@ func TestCoordinatorE2E(t *testing.T) {
 		}},
 		Servers: []model.ServerAddress{sa1, sa2, sa3},
 	}
-	clientPool := common.NewClientPool()
+	clientPool := common.NewClientPool(nil, nil)
 
 	coordinator, err := NewCoordinator(metadataProvider, func() (model.ClusterConfig, error) { return clusterConfig, nil }, 0, NewRpcProvider(clientPool))
 
@@ -102,7 +102,7 @@ func TestCoordinatorE2E_ShardsRanges(t *testing.T) {
 		}},
 		Servers: []model.ServerAddress{sa1, sa2, sa3},
 	}
-	clientPool := common.NewClientPool()
+	clientPool := common.NewClientPool(nil, nil)
 
 	coordinator, err := NewCoordinator(metadataProvider, func() (model.ClusterConfig, error) { return clusterConfig, nil }, 0, NewRpcProvider(clientPool))
 	assert.NoError(t, err)
@@ -154,7 +154,7 @@ func TestCoordinator_LeaderFailover(t *testing.T) {
 		}},
 		Servers: []model.ServerAddress{sa1, sa2, sa3},
 	}
-	clientPool := common.NewClientPool()
+	clientPool := common.NewClientPool(nil, nil)
 
 	coordinator, err := NewCoordinator(metadataProvider, func() (model.ClusterConfig, error) { return clusterConfig, nil }, 0, NewRpcProvider(clientPool))
 	assert.NoError(t, err)
@@ -256,7 +256,7 @@ func TestCoordinator_MultipleNamespaces(t *testing.T) {
 		}},
 		Servers: []model.ServerAddress{sa1, sa2, sa3},
 	}
-	clientPool := common.NewClientPool()
+	clientPool := common.NewClientPool(nil, nil)
 
 	coordinator, err := NewCoordinator(metadataProvider, func() (model.ClusterConfig, error) { return clusterConfig, nil }, 0, NewRpcProvider(clientPool))
 	assert.NoError(t, err)
@@ -347,7 +347,7 @@ func TestCoordinator_DeleteNamespace(t *testing.T) {
 		}},
 		Servers: []model.ServerAddress{sa1, sa2, sa3},
 	}
-	clientPool := common.NewClientPool()
+	clientPool := common.NewClientPool(nil, nil)
 
 	coordinator, err := NewCoordinator(metadataProvider, func() (model.ClusterConfig, error) { return clusterConfig, nil }, 0, NewRpcProvider(clientPool))
 	assert.NoError(t, err)
@@ -413,7 +413,7 @@ func TestCoordinator_DynamicallAddNamespace(t *testing.T) {
 		}},
 		Servers: []model.ServerAddress{sa1, sa2, sa3},
 	}
-	clientPool := common.NewClientPool()
+	clientPool := common.NewClientPool(nil, nil)
 
 	configProvider := func() (model.ClusterConfig, error) {
 		return clusterConfig, nil
@@ -499,7 +499,7 @@ func TestCoordinator_RebalanceCluster(t *testing.T) {
 		}},
 		Servers: []model.ServerAddress{sa1, sa2, sa3},
 	}
-	clientPool := common.NewClientPool()
+	clientPool := common.NewClientPool(nil, nil)
 	mutex := &sync.Mutex{}
 
 	configProvider := func() (model.ClusterConfig, error) {
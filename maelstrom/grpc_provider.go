@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
@@ -49,7 +50,8 @@ func newMaelstromGrpcProvider() *maelstromGrpcProvider {
 	}
 }
 
-func (m *maelstromGrpcProvider) StartGrpcServer(name, bindAddress string, registerFunc func(grpc.ServiceRegistrar)) (container.GrpcServer, error) {
+func (m *maelstromGrpcProvider) StartGrpcServer(name, bindAddress string, registerFunc func(grpc.ServiceRegistrar), _ *tls.Config,
+	_ ...grpc.ServerOption) (container.GrpcServer, error) {
 	log.Info().
 		Str("name", name).
 		Msg("Start Grpc server")
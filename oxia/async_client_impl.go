@@ -27,15 +27,22 @@ import (
 	"oxia/oxia/internal/metrics"
 	"oxia/oxia/internal/model"
 	"oxia/proto"
+	"strconv"
+	"strings"
 	"sync"
 )
 
+// defaultListPageSize is the maximum number of keys in each [ListResult],
+// when no [Limit] is set
+const defaultListPageSize = 1000
+
 type clientImpl struct {
 	sync.Mutex
 	options           clientOptions
 	shardManager      internal.ShardManager
 	writeBatchManager *batch.Manager
 	readBatchManager  *batch.Manager
+	batcherFactory    *batch.BatcherFactory
 	executor          internal.Executor
 	sessions          *sessions
 
@@ -54,13 +61,13 @@ type clientImpl struct {
 //
 //	client, err := oxia.NewAsyncClient("my-oxia-service:6648", oxia.WithBatchLinger(10*time.Milliseconds))
 func NewAsyncClient(serviceAddress string, opts ...ClientOption) (AsyncClient, error) {
-	clientPool := common.NewClientPool()
-
 	options, err := newClientOptions(serviceAddress, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	clientPool := common.NewClientPool(options.tlsConf, options.authentication)
+
 	shardManager, err := internal.NewShardManager(internal.NewShardStrategy(), clientPool, serviceAddress,
 		options.namespace, options.requestTimeout)
 	if err != nil {
@@ -86,6 +93,7 @@ func NewAsyncClient(serviceAddress string, opts ...ClientOption) (AsyncClient, e
 			return batcherFactory.NewWriteBatcher(shard, options.maxBatchSize)
 		}),
 		readBatchManager: batch.NewManager(batcherFactory.NewReadBatcher),
+		batcherFactory:   batcherFactory,
 		executor:         executor,
 	}
 
@@ -106,22 +114,71 @@ func (c *clientImpl) Close() error {
 
 func (c *clientImpl) Put(key string, value []byte, options ...PutOption) <-chan PutResult {
 	ch := make(chan PutResult, 1)
-	shardId := c.shardManager.Get(key)
-	callback := func(response *proto.PutResponse, err error) {
+	c.put(key, value, proto.PutOperation_SET, options, func(response *proto.PutResponse, err error) {
 		if err != nil {
 			ch <- PutResult{Err: err}
 		} else {
-			ch <- toPutResult(response)
+			ch <- toPutResult(key, response)
 		}
 		close(ch)
-	}
+	})
+	return ch
+}
+
+func (c *clientImpl) Increment(key string, delta int64, options ...PutOption) <-chan IncrementResult {
+	ch := make(chan IncrementResult, 1)
+	value := []byte(strconv.FormatInt(delta, 10))
+	c.put(key, value, proto.PutOperation_ADD, options, func(response *proto.PutResponse, err error) {
+		if err != nil {
+			ch <- IncrementResult{Err: err}
+		} else {
+			ch <- toIncrementResult(key, response)
+		}
+		close(ch)
+	})
+	return ch
+}
+
+func (c *clientImpl) Append(key string, value []byte, options ...PutOption) <-chan AppendResult {
+	ch := make(chan AppendResult, 1)
+	c.put(key, value, proto.PutOperation_APPEND, options, func(response *proto.PutResponse, err error) {
+		if err != nil {
+			ch <- AppendResult{Err: err}
+		} else {
+			ch <- toAppendResult(key, response)
+		}
+		close(ch)
+	})
+	return ch
+}
+
+func (c *clientImpl) put(key string, value []byte, operation proto.PutOperation, options []PutOption,
+	callback func(*proto.PutResponse, error)) {
 	opts := newPutOptions(options)
+	shardId := c.shardManager.Get(shardingKey(key, opts.partitionKey))
+	if opts.previous != nil {
+		resultCallback := callback
+		callback = func(response *proto.PutResponse, err error) {
+			if err == nil {
+				setPrevious(opts.previous, key, response.Status, response.PreviousValue, response.PreviousVersion)
+			}
+			resultCallback(response, err)
+		}
+	}
 	putCall := model.PutCall{
 		Key:               key,
 		Value:             value,
 		ExpectedVersionId: opts.expectedVersion,
+		SequenceKeyDeltas: opts.sequenceKeyDeltas,
+		TtlMs:             opts.ttlMs(),
+		Operation:         operation,
+		ReturnPrevious:    opts.previous != nil,
 		Callback:          callback,
 	}
+	if err := opts.validate(); err != nil {
+		callback(nil, err)
+		return
+	}
 	if opts.ephemeral {
 		putCall.ClientIdentity = &c.options.identity
 		c.sessions.executeWithSessionId(shardId, func(sessionId int64, err error) {
@@ -135,24 +192,25 @@ func (c *clientImpl) Put(key string, value []byte, options ...PutOption) <-chan
 	} else {
 		c.writeBatchManager.Get(shardId).Add(putCall)
 	}
-	return ch
 }
 
 func (c *clientImpl) Delete(key string, options ...DeleteOption) <-chan error {
 	ch := make(chan error, 1)
-	shardId := c.shardManager.Get(key)
+	opts := newDeleteOptions(options)
+	shardId := c.shardManager.Get(shardingKey(key, opts.partitionKey))
 	callback := func(response *proto.DeleteResponse, err error) {
 		if err != nil {
 			ch <- err
 		} else {
+			setPrevious(opts.previous, key, response.Status, response.PreviousValue, response.PreviousVersion)
 			ch <- toDeleteResult(response)
 		}
 		close(ch)
 	}
-	opts := newDeleteOptions(options)
 	c.writeBatchManager.Get(shardId).Add(model.DeleteCall{
 		Key:               key,
 		ExpectedVersionId: opts.expectedVersion,
+		ReturnPrevious:    opts.previous != nil,
 		Callback:          callback,
 	})
 	return ch
@@ -187,73 +245,405 @@ func (c *clientImpl) DeleteRange(minKeyInclusive string, maxKeyExclusive string)
 	return ch
 }
 
-func (c *clientImpl) Get(key string) <-chan GetResult {
-	ch := make(chan GetResult)
-	shardId := c.shardManager.Get(key)
-	callback := func(response *proto.GetResponse, err error) {
-		if err != nil {
-			ch <- GetResult{Err: err}
-		} else {
-			ch <- toGetResult(response)
+func (c *clientImpl) Get(key string, options ...GetOption) <-chan GetResult {
+	ch := make(chan GetResult, 1)
+	c.get(key, true, newGetOptions(options), func(r GetResult) {
+		ch <- r
+		close(ch)
+	})
+	return ch
+}
+
+func (c *clientImpl) Stat(key string, options ...GetOption) <-chan GetResult {
+	ch := make(chan GetResult, 1)
+	c.get(key, false, newGetOptions(options), func(r GetResult) {
+		ch <- r
+		close(ch)
+	})
+	return ch
+}
+
+func (c *clientImpl) Exists(key string, options ...GetOption) <-chan ExistsResult {
+	ch := make(chan ExistsResult, 1)
+	c.get(key, false, newGetOptions(options), func(r GetResult) {
+		switch {
+		case errors.Is(r.Err, ErrorKeyNotFound):
+			ch <- ExistsResult{Exists: false}
+		case r.Err != nil:
+			ch <- ExistsResult{Err: r.Err}
+		default:
+			ch <- ExistsResult{Exists: true, Version: r.Version}
 		}
 		close(ch)
+	})
+	return ch
+}
+
+// get reads a record, optionally without transferring its value, and passes
+// the result to the callback
+func (c *clientImpl) get(key string, includeValue bool, opts getOptions, callback func(GetResult)) {
+	if opts.comparisonType == proto.KeyComparisonType_EQUAL || opts.partitionKey != nil {
+		c.getFromShard(c.shardManager.Get(shardingKey(key, opts.partitionKey)), key, includeValue, opts, callback)
+	} else {
+		c.getFromAllShards(key, includeValue, opts, callback)
 	}
+}
+
+func (c *clientImpl) getFromShard(shardId int64, key string, includeValue bool, opts getOptions, callback func(GetResult)) {
 	c.readBatchManager.Get(shardId).Add(model.GetCall{
-		Key:      key,
-		Callback: callback,
+		Key:            key,
+		ComparisonType: opts.comparisonType,
+		IncludeValue:   includeValue,
+		Callback: func(response *proto.GetResponse, err error) {
+			if err != nil {
+				callback(GetResult{Err: err})
+			} else {
+				callback(toGetResult(key, response))
+			}
+		},
 	})
-	return ch
 }
 
-func (c *clientImpl) List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan ListResult {
+// getFromAllShards looks up the matching key in each shard, since with the
+// hash-based sharding any of them could hold the closest key, and picks the
+// best of the answers.
+func (c *clientImpl) getFromAllShards(key string, includeValue bool, opts getOptions, callback func(GetResult)) {
 	shardIds := c.shardManager.GetAll()
-	ch := make(chan ListResult)
-	wg := common.NewWaitGroup(len(shardIds))
+	results := make(chan GetResult, len(shardIds))
 	for _, shardId := range shardIds {
-		shardIdPtr := &shardId
+		c.readBatchManager.Get(shardId).Add(model.GetCall{
+			Key:            key,
+			ComparisonType: opts.comparisonType,
+			IncludeValue:   includeValue,
+			Callback: func(response *proto.GetResponse, err error) {
+				if err != nil {
+					results <- GetResult{Err: err}
+				} else {
+					results <- toGetResult(key, response)
+				}
+			},
+		})
+	}
+
+	go func() {
+		var best *GetResult
+		var err error
+		for range shardIds {
+			r := <-results
+			if errors.Is(r.Err, ErrorKeyNotFound) {
+				continue
+			} else if r.Err != nil {
+				err = r.Err
+			} else if best == nil || isCloserKey(opts.comparisonType, r.Version.Key, best.Version.Key) {
+				best = &r
+			}
+		}
+
+		switch {
+		case err != nil:
+			callback(GetResult{Err: err})
+		case best == nil:
+			callback(GetResult{Err: ErrorKeyNotFound})
+		default:
+			callback(*best)
+		}
+	}()
+}
+
+// isCloserKey checks whether key a is a better match than key b
+func isCloserKey(comparisonType proto.KeyComparisonType, a, b string) bool {
+	res := common.CompareWithSlash([]byte(a), []byte(b))
+	switch comparisonType {
+	case proto.KeyComparisonType_FLOOR, proto.KeyComparisonType_LOWER:
+		return res > 0
+	default:
+		return res < 0
+	}
+}
+
+func (c *clientImpl) List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string, options ...ListOption) <-chan ListResult {
+	ch := make(chan ListResult)
+	opts := newListOptions(options)
+	if err := opts.validate(); err != nil {
 		go func() {
-			request := &proto.ListRequest{
-				ShardId:        shardIdPtr,
-				StartInclusive: minKeyInclusive,
-				EndExclusive:   maxKeyExclusive,
+			ch <- ListResult{Err: err}
+			close(ch)
+		}()
+		return ch
+	}
+
+	var shardIds []int64
+	if opts.partitionKey != nil {
+		shardIds = []int64{c.shardManager.Get(*opts.partitionKey)}
+	} else {
+		shardIds = c.shardManager.GetAll()
+	}
+
+	// Stop reading from the shards as soon as the page is complete
+	ctx, cancel := context.WithCancel(ctx)
+
+	shardChannels := make([]<-chan listItem, len(shardIds))
+	for i, shardId := range shardIds {
+		shardId := shardId
+		// Each shard can contribute up to the whole page, plus one key to
+		// tell whether there are more
+		shardChannels[i] = c.listShard(ctx, &proto.ListRequest{
+			ShardId:        &shardId,
+			StartInclusive: minKeyInclusive,
+			EndExclusive:   maxKeyExclusive,
+			Limit:          opts.limitProto(),
+			Reverse:        opts.reverse,
+			Continuation:   opts.continuation,
+		})
+	}
+
+	merged := make(chan listItem)
+	go mergeSorted(ctx, shardChannels, merged, func(a, b listItem) bool {
+		res := common.CompareWithSlash([]byte(a.key), []byte(b.key))
+		if opts.reverse {
+			return res > 0
+		}
+		return res < 0
+	}, func(i listItem) bool {
+		return i.err != nil
+	})
+
+	go func() {
+		defer close(ch)
+		defer cancel()
+		paginate(ctx, merged, opts.limit, ch)
+	}()
+	return ch
+}
+
+func (c *clientImpl) ListChildren(ctx context.Context, path string, options ...ListOption) <-chan ListResult {
+	minKeyInclusive, maxKeyExclusive := childrenRange(path)
+	return c.List(ctx, minKeyInclusive, maxKeyExclusive, options...)
+}
+
+func (c *clientImpl) DeleteRecursive(path string) <-chan error {
+	path = strings.TrimSuffix(path, "/")
+	ch := make(chan error, 1)
+
+	var deleteCh <-chan error
+	if path != "" {
+		deleteCh = c.Delete(path)
+	}
+	minKeyInclusive, maxKeyExclusive := descendantsRange(path)
+	deleteRangeCh := c.DeleteRange(minKeyInclusive, maxKeyExclusive)
+
+	go func() {
+		var err error
+		if deleteCh != nil {
+			if err = <-deleteCh; errors.Is(err, ErrorKeyNotFound) {
+				// The path itself is not required to be a record
+				err = nil
 			}
+		}
+		ch <- multierr.Append(err, <-deleteRangeCh)
+		close(ch)
+	}()
+	return ch
+}
 
-			client, err := c.executor.ExecuteList(ctx, request)
-			if err != nil {
-				ch <- ListResult{Err: err}
-				wg.Done()
+// childrenRange returns the range of keys that holds the direct children of
+// the path. Since a key sorts after all the keys with fewer `/` segments under
+// the same parent, `<path>/` and `<path>//` delimit the keys with exactly one
+// more segment.
+func childrenRange(path string) (minKeyInclusive string, maxKeyExclusive string) {
+	path = strings.TrimSuffix(path, "/")
+	return path + "/", path + "//"
+}
+
+// descendantsRange returns the range of keys that holds all the descendants
+// of the path, at any depth. The keys that start with the segments of the path
+// and have more segments sort after `<path>/`, and before `<path>\x00/`, which
+// has the smallest last segment that is greater than the one of the path.
+func descendantsRange(path string) (minKeyInclusive string, maxKeyExclusive string) {
+	path = strings.TrimSuffix(path, "/")
+	return path + "/", path + "\x00/"
+}
+
+type listItem struct {
+	key string
+	err error
+}
+
+// paginate groups the sorted keys into results of at most one page. With a
+// limit, it stops after the first page and sets the continuation if more keys
+// are available.
+func paginate(ctx context.Context, items <-chan listItem, limit *int, ch chan<- ListResult) {
+	pageSize := defaultListPageSize
+	if limit != nil {
+		pageSize = *limit
+	}
+
+	keys := make([]string, 0)
+	count := 0
+	for item := range items {
+		if item.err != nil {
+			sendOrDone(ctx, ch, ListResult{Keys: keys, Err: item.err})
+			return
+		}
+
+		if limit != nil && count == *limit {
+			sendOrDone(ctx, ch, ListResult{Keys: keys, Continuation: keys[len(keys)-1]})
+			return
+		}
+
+		keys = append(keys, item.key)
+		count++
+		if limit == nil && len(keys) == pageSize {
+			if !sendOrDone(ctx, ch, ListResult{Keys: keys}) {
+				return
+			}
+			keys = make([]string, 0)
+		}
+	}
+
+	if len(keys) > 0 {
+		sendOrDone(ctx, ch, ListResult{Keys: keys})
+	}
+}
+
+func (c *clientImpl) listShard(ctx context.Context, request *proto.ListRequest) <-chan listItem {
+	ch := make(chan listItem)
+	go func() {
+		defer close(ch)
+
+		client, err := c.executor.ExecuteList(ctx, request)
+		if err != nil {
+			sendOrDone(ctx, ch, listItem{err: err})
+			return
+		}
+
+		for {
+			response, err := client.Recv()
+			if err == io.EOF {
+				return
+			} else if err != nil {
+				sendOrDone(ctx, ch, listItem{err: err})
 				return
 			}
 
-			for {
-				response, err := client.Recv()
-				if err == io.EOF {
-					break
-				} else if err != nil {
-					ch <- ListResult{Err: err}
-					break
+			for _, key := range response.Keys {
+				if !sendOrDone(ctx, ch, listItem{key: key}) {
+					return
 				}
-				ch <- ListResult{Keys: response.Keys}
 			}
+		}
+	}()
+	return ch
+}
 
-			wg.Done()
-		}()
+func (c *clientImpl) RangeScan(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan GetResult {
+	// Stop reading from the shards as soon as the merge is over
+	ctx, cancel := context.WithCancel(ctx)
 
+	shardIds := c.shardManager.GetAll()
+	shardChannels := make([]<-chan GetResult, len(shardIds))
+	for i, shardId := range shardIds {
+		shardChannels[i] = c.rangeScanShard(ctx, shardId, minKeyInclusive, maxKeyExclusive)
 	}
 
+	ch := make(chan GetResult)
 	go func() {
-		_ = wg.Wait(ctx)
-		close(ch)
+		defer cancel()
+		mergeSorted(ctx, shardChannels, ch, func(a, b GetResult) bool {
+			return common.CompareWithSlash([]byte(a.Version.Key), []byte(b.Version.Key)) < 0
+		}, func(r GetResult) bool {
+			return r.Err != nil
+		})
 	}()
+	return ch
+}
 
+func (c *clientImpl) rangeScanShard(ctx context.Context, shardId int64, minKeyInclusive string, maxKeyExclusive string) <-chan GetResult {
+	ch := make(chan GetResult)
+	go func() {
+		defer close(ch)
+
+		request := &proto.RangeScanRequest{
+			ShardId:        &shardId,
+			StartInclusive: minKeyInclusive,
+			EndExclusive:   maxKeyExclusive,
+		}
+
+		client, err := c.executor.ExecuteRangeScan(ctx, request)
+		if err != nil {
+			sendOrDone(ctx, ch, GetResult{Err: err})
+			return
+		}
+
+		for {
+			response, err := client.Recv()
+			if err == io.EOF {
+				return
+			} else if err != nil {
+				sendOrDone(ctx, ch, GetResult{Err: err})
+				return
+			}
+
+			for _, record := range response.Records {
+				if !sendOrDone(ctx, ch, toGetResult(record.GetKey(), record)) {
+					return
+				}
+			}
+		}
+	}()
 	return ch
 }
 
-func (c *clientImpl) GetNotifications() (Notifications, error) {
-	nm, err := newNotifications(c.options, c.ctx, c.clientPool, c.shardManager)
+func (c *clientImpl) CountRange(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan CountRangeResult {
+	shardIds := c.shardManager.GetAll()
+	ch := make(chan CountRangeResult, 1)
+	responses := make([]*proto.CountRangeResponse, len(shardIds))
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, shardId := range shardIds {
+		i, shardId := i, shardId
+		eg.Go(func() (err error) {
+			responses[i], err = c.executor.ExecuteCountRange(egCtx, &proto.CountRangeRequest{
+				ShardId:        &shardId,
+				StartInclusive: minKeyInclusive,
+				EndExclusive:   maxKeyExclusive,
+			})
+			return err
+		})
+	}
+	go func() {
+		if err := eg.Wait(); err != nil {
+			ch <- CountRangeResult{Err: err}
+		} else {
+			ch <- toCountRangeResult(responses)
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+func (c *clientImpl) GetNotifications(options ...NotificationsOption) (Notifications, error) {
+	nm, err := newNotifications(c.options, newNotificationsOptions(options), c.ctx, c.clientPool, c.shardManager)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to create notification stream")
 	}
 
 	return nm, nil
 }
+
+func (c *clientImpl) SessionDone(partitionKey string) <-chan SessionDoneResult {
+	ch := make(chan SessionDoneResult, 1)
+	shardId := c.shardManager.Get(partitionKey)
+	go c.sessions.executeWithSession(shardId, func(session *clientSession, err error) {
+		if err != nil {
+			ch <- SessionDoneResult{Err: err}
+		} else {
+			ch <- SessionDoneResult{Done: session.done}
+		}
+		close(ch)
+	})
+	return ch
+}
+
+func (c *clientImpl) Txn() AsyncTxn {
+	return newAsyncTxn(c)
+}
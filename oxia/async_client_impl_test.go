@@ -20,8 +20,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
+	"os"
 	"oxia/common"
+	"oxia/common/security"
 	"oxia/server"
+	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -329,3 +333,842 @@ func TestAsyncClientImpl_ClientIdentity(t *testing.T) {
 	assert.NoError(t, client1.Close())
 	assert.NoError(t, client2.Close())
 }
+
+func TestSyncClientImpl_Txn(t *testing.T) {
+	client, err := NewSyncClient(serviceAddress)
+	assert.NoError(t, err)
+
+	k1 := newKey()
+	k2 := newKey()
+	versions, err := client.Txn().
+		Put(k1, []byte("v1"), ExpectedRecordNotExists()).
+		Put(k2, []byte("v2")).
+		Commit(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.EqualValues(t, 0, versions[0].ModificationsCount)
+	assert.EqualValues(t, 0, versions[1].ModificationsCount)
+
+	// The condition on k1 fails, so k2 must not be deleted
+	versions, err = client.Txn().
+		Put(k1, []byte("v3"), ExpectedRecordNotExists()).
+		Delete(k2).
+		Commit(context.Background())
+	assert.ErrorIs(t, err, ErrorUnexpectedVersionId)
+	assert.Nil(t, versions)
+
+	value, version, err := client.Get(context.Background(), k1)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", string(value))
+
+	value, _, err = client.Get(context.Background(), k2)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", string(value))
+
+	versions, err = client.Txn().
+		Put(k1, []byte("v4"), ExpectedVersionId(version.VersionId)).
+		Delete(k2).
+		Commit(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, versions, 1)
+	assert.EqualValues(t, 1, versions[0].ModificationsCount)
+
+	_, _, err = client.Get(context.Background(), k2)
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	assert.NoError(t, client.Close())
+}
+
+func TestSyncClientImpl_TxnMultipleShards(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NumShards = 4
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	txn := client.Txn()
+	for i := 0; i < 100; i++ {
+		txn.Put(fmt.Sprintf("key-%d", i), []byte("v"))
+	}
+	_, err = txn.Commit(context.Background())
+	assert.ErrorIs(t, err, ErrorTransactionSpansShards)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_PartitionKey(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NumShards = 4
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	keys := make([]string, 0)
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("/job/%d", i)
+		_, err = client.Put(context.Background(), key, []byte("v"), PartitionKey("x"))
+		assert.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	// All the records are stored in the shard of the partition key
+	listResult := <-client.List(context.Background(), "/job/", "/job//", PartitionKey("x"))
+	assert.NoError(t, listResult.Err)
+	assert.Equal(t, keys, listResult.Keys)
+
+	value, _, err := client.Get(context.Background(), "/job/3", PartitionKey("x"))
+	assert.NoError(t, err)
+	assert.Equal(t, "v", string(value))
+
+	// Records with the same partition key can be updated in a transaction
+	_, err = client.Txn().
+		Put("/job/1", []byte("v1"), PartitionKey("x")).
+		Delete("/job/2", PartitionKey("x")).
+		Commit(context.Background())
+	assert.NoError(t, err)
+
+	err = client.Delete(context.Background(), "/job/1", PartitionKey("x"))
+	assert.NoError(t, err)
+
+	_, _, err = client.Get(context.Background(), "/job/2", PartitionKey("x"))
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_SequentialKeys(t *testing.T) {
+	client, err := NewSyncClient(serviceAddress)
+	assert.NoError(t, err)
+
+	prefix := newKey()
+	_, err = client.Put(context.Background(), prefix, []byte("0"), SequentialKeysDeltas(1))
+	assert.ErrorIs(t, err, ErrorInvalidSequentialKeys)
+
+	_, err = client.Put(context.Background(), prefix, []byte("0"), SequentialKeysDeltas(0), PartitionKey(prefix))
+	assert.ErrorIs(t, err, ErrorInvalidSequentialKeys)
+
+	v1, err := client.Put(context.Background(), prefix, []byte("0"), SequentialKeysDeltas(1), PartitionKey(prefix))
+	assert.NoError(t, err)
+	assert.Equal(t, prefix+"-00000000000000000001", v1.Key)
+
+	v2, err := client.Put(context.Background(), prefix, []byte("1"), SequentialKeysDeltas(1), PartitionKey(prefix))
+	assert.NoError(t, err)
+	assert.Equal(t, prefix+"-00000000000000000002", v2.Key)
+
+	value, version, err := client.Get(context.Background(), v2.Key, PartitionKey(prefix))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", string(value))
+	assert.Equal(t, v2, version)
+
+	assert.NoError(t, client.Close())
+}
+
+func TestSyncClientImpl_TTL(t *testing.T) {
+	server, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", server.RpcPort()))
+	assert.NoError(t, err)
+
+	_, err = client.Put(context.Background(), "/a", []byte("0"), WithTTL(0))
+	assert.ErrorIs(t, err, ErrorInvalidOptionTTL)
+
+	notifications, err := client.GetNotifications()
+	assert.NoError(t, err)
+
+	_, err = client.Put(context.Background(), "/a", []byte("0"), WithTTL(100*time.Millisecond))
+	assert.NoError(t, err)
+	_, err = client.Put(context.Background(), "/b", []byte("0"))
+	assert.NoError(t, err)
+
+	n := <-notifications.Ch()
+	assert.Equal(t, KeyCreated, n.Type)
+	assert.Equal(t, "/a", n.Key)
+
+	n = <-notifications.Ch()
+	assert.Equal(t, KeyCreated, n.Type)
+	assert.Equal(t, "/b", n.Key)
+
+	select {
+	case n = <-notifications.Ch():
+		assert.Equal(t, KeyDeleted, n.Type)
+		assert.Equal(t, "/a", n.Key)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "the record should have expired")
+	}
+
+	_, _, err = client.Get(context.Background(), "/a")
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	// Records without ttl are not affected
+	_, _, err = client.Get(context.Background(), "/b")
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, server.Close())
+}
+
+func TestSyncClientImpl_RangeScan(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NumShards = 4
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	keys := []string{"/a", "/a/b", "/b", "/c", "/c/d", "/d", "/e", "/e/f/g"}
+	for _, key := range keys {
+		_, err = client.Put(context.Background(), key, []byte(key))
+		assert.NoError(t, err)
+	}
+
+	results := make([]GetResult, 0)
+	for r := range client.RangeScan(context.Background(), "/a", "/e") {
+		assert.NoError(t, r.Err)
+		results = append(results, r)
+	}
+
+	// The records are sorted across all the shards, with the `/` aware ordering
+	assert.Len(t, results, 4)
+	for i, key := range []string{"/a", "/b", "/c", "/d"} {
+		assert.Equal(t, key, results[i].Version.Key)
+		assert.Equal(t, key, string(results[i].Value))
+	}
+
+	results = make([]GetResult, 0)
+	for r := range client.RangeScan(context.Background(), "/c/", "/c//") {
+		assert.NoError(t, r.Err)
+		results = append(results, r)
+	}
+	assert.Len(t, results, 1)
+	assert.Equal(t, "/c/d", results[0].Version.Key)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_IncrementAppend(t *testing.T) {
+	standalone, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// Concurrent increments never conflict
+	const increments = 20
+	wg := sync.WaitGroup{}
+	wg.Add(increments)
+	for i := 0; i < increments; i++ {
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Increment(ctx, "/counter", 2)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	value, version, err := client.Increment(ctx, "/counter", -10)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 30, value)
+	assert.Equal(t, "/counter", version.Key)
+	assert.EqualValues(t, increments, version.ModificationsCount)
+
+	res, _, err := client.Get(ctx, "/counter")
+	assert.NoError(t, err)
+	assert.Equal(t, "30", string(res))
+
+	_, err = client.Put(ctx, "/text", []byte("hello"))
+	assert.NoError(t, err)
+	_, _, err = client.Increment(ctx, "/text", 1)
+	assert.ErrorIs(t, err, ErrorInvalidValue)
+
+	_, _, err = client.Increment(ctx, "/counter", 1, ExpectedVersionId(version.VersionId+1))
+	assert.ErrorIs(t, err, ErrorUnexpectedVersionId)
+
+	appended, _, err := client.Append(ctx, "/log", []byte("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a", string(appended))
+	appended, version, err = client.Append(ctx, "/log", []byte("bc"), PartitionKey("/log"))
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", string(appended))
+	assert.EqualValues(t, 1, version.ModificationsCount)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_ReturnPrevious(t *testing.T) {
+	standalone, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	var previous PreviousRecord
+	v1, err := client.Put(ctx, "/a", []byte("v1"), ReturnPrevious(&previous))
+	assert.NoError(t, err)
+	assert.False(t, previous.Exists)
+
+	v2, err := client.Put(ctx, "/a", []byte("v2"), ReturnPrevious(&previous))
+	assert.NoError(t, err)
+	assert.True(t, previous.Exists)
+	assert.Equal(t, "v1", string(previous.Value))
+	assert.Equal(t, v1, previous.Version)
+
+	// A failed operation does not change the previous record
+	_, err = client.Put(ctx, "/a", []byte("v3"), ExpectedVersionId(v1.VersionId), ReturnPrevious(&previous))
+	assert.ErrorIs(t, err, ErrorUnexpectedVersionId)
+	assert.Equal(t, "v1", string(previous.Value))
+
+	_, _, err = client.Increment(ctx, "/counter", 5, ReturnPrevious(&previous))
+	assert.NoError(t, err)
+	assert.False(t, previous.Exists)
+
+	assert.NoError(t, client.Delete(ctx, "/a", ReturnPrevious(&previous)))
+	assert.True(t, previous.Exists)
+	assert.Equal(t, "v2", string(previous.Value))
+	assert.Equal(t, v2, previous.Version)
+
+	// Transactions
+	var previousPut, previousDelete PreviousRecord
+	_, err = client.Txn().
+		Put("/counter", []byte("10"), ReturnPrevious(&previousPut)).
+		Delete("/counter", ReturnPrevious(&previousDelete)).
+		Commit(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", string(previousPut.Value))
+	assert.Equal(t, "10", string(previousDelete.Value))
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_StatExists(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NumShards = 4
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	v1, err := client.Put(ctx, "/a", []byte("v1"))
+	assert.NoError(t, err)
+
+	version, err := client.Stat(ctx, "/a")
+	assert.NoError(t, err)
+	assert.Equal(t, v1, version)
+
+	_, err = client.Stat(ctx, "/b")
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	exists, err := client.Exists(ctx, "/a")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.Exists(ctx, "/b")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	version, err = client.Stat(ctx, "/b", ComparisonFloor())
+	assert.NoError(t, err)
+	assert.Equal(t, "/a", version.Key)
+
+	// The value is not transferred
+	asyncClient := client.(*syncClientImpl).asyncClient
+	r := <-asyncClient.Stat("/a")
+	assert.NoError(t, r.Err)
+	assert.Nil(t, r.Value)
+
+	e := <-asyncClient.Exists("/a")
+	assert.NoError(t, e.Err)
+	assert.True(t, e.Exists)
+	assert.Equal(t, v1, e.Version)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_CountRange(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NumShards = 4
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	for _, key := range []string{"/tenant/x/a", "/tenant/x/b", "/tenant/x/c", "/tenant/y/a"} {
+		_, err = client.Put(ctx, key, []byte(key))
+		assert.NoError(t, err)
+	}
+
+	count, size, err := client.CountRange(ctx, "/tenant/x/", "/tenant/x//")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	assert.EqualValues(t, 3*len("/tenant/x/a"), size)
+
+	count, size, err = client.CountRange(ctx, "/tenant/z/", "/tenant/z//")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+	assert.EqualValues(t, 0, size)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_ListChildrenDeleteRecursive(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NumShards = 4
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	subtree := []string{"/xyz", "/xyz/A", "/xyz/B", "/xyz/A/1", "/xyz/A/2", "/xyz/B/1", "/xyz/A/1/a", "/xyz/C/1/a"}
+	others := []string{"/xy", "/xyz-1", "/xyz-1/A", "/xyz0/A", "/xy/z", "/w/xyz/A"}
+	for _, key := range append(subtree, others...) {
+		_, err = client.Put(ctx, key, []byte(key))
+		assert.NoError(t, err)
+	}
+
+	listChildren := func(path string) []string {
+		keys := make([]string, 0)
+		for r := range client.ListChildren(ctx, path) {
+			assert.NoError(t, r.Err)
+			keys = append(keys, r.Keys...)
+		}
+		return keys
+	}
+
+	assert.Equal(t, []string{"/xyz/A", "/xyz/B"}, listChildren("/xyz"))
+	assert.Equal(t, []string{"/xyz/A", "/xyz/B"}, listChildren("/xyz/"))
+	assert.Equal(t, []string{"/xyz/A/1", "/xyz/A/2"}, listChildren("/xyz/A"))
+	assert.Equal(t, []string{"/xyz/C/1/a"}, listChildren("/xyz/C/1"))
+	assert.Empty(t, listChildren("/xyz/C/1/a"))
+
+	notifications, err := client.GetNotifications(FilterKeyPrefix("/xyz"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.DeleteRecursive(ctx, "/xyz"))
+
+	for _, key := range subtree {
+		_, _, err = client.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrorKeyNotFound, key)
+	}
+	for _, key := range others {
+		_, _, err = client.Get(ctx, key)
+		assert.NoError(t, err, key)
+	}
+
+	deleted := make([]string, 0)
+	for range subtree {
+		select {
+		case n := <-notifications.Ch():
+			assert.Equal(t, KeyDeleted, n.Type)
+			deleted = append(deleted, n.Key)
+		case <-time.After(10 * time.Second):
+			assert.FailNow(t, "notification not received")
+		}
+	}
+	assert.ElementsMatch(t, subtree, deleted)
+
+	// The path is not required to be a record
+	assert.NoError(t, client.DeleteRecursive(ctx, "/w/"))
+	_, _, err = client.Get(ctx, "/w/xyz/A")
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	assert.NoError(t, notifications.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_ListPaginated(t *testing.T) {
+	// With a single shard, the continuation can't rely on the keys of the
+	// other shards
+	for _, numShards := range []uint32{1, 4} {
+		t.Run(fmt.Sprintf("shards-%d", numShards), func(t *testing.T) {
+			testSyncClientImplListPaginated(t, numShards)
+		})
+	}
+}
+
+func testSyncClientImplListPaginated(t *testing.T, numShards uint32) {
+	config := server.NewTestConfig()
+	config.NumShards = numShards
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	for _, key := range []string{"/a", "/b", "/c", "/d", "/e", "/f", "/g"} {
+		_, err = client.Put(context.Background(), key, []byte(key))
+		assert.NoError(t, err)
+	}
+
+	readPage := func(options ...ListOption) ([]string, string) {
+		keys := make([]string, 0)
+		continuation := ""
+		for r := range client.List(context.Background(), "/a", "/g", options...) {
+			assert.NoError(t, r.Err)
+			keys = append(keys, r.Keys...)
+			continuation = r.Continuation
+		}
+		return keys, continuation
+	}
+
+	// Without limit, all the keys are returned in order across the shards
+	keys, continuation := readPage()
+	assert.Equal(t, []string{"/a", "/b", "/c", "/d", "/e", "/f"}, keys)
+	assert.Empty(t, continuation)
+
+	keys, continuation = readPage(Limit(4))
+	assert.Equal(t, []string{"/a", "/b", "/c", "/d"}, keys)
+	assert.NotEmpty(t, continuation)
+
+	keys, continuation = readPage(Limit(4), ContinueFrom(continuation))
+	assert.Equal(t, []string{"/e", "/f"}, keys)
+	assert.Empty(t, continuation)
+
+	keys, continuation = readPage(Limit(3), Reverse())
+	assert.Equal(t, []string{"/f", "/e", "/d"}, keys)
+	assert.NotEmpty(t, continuation)
+
+	keys, continuation = readPage(Limit(3), Reverse(), ContinueFrom(continuation))
+	assert.Equal(t, []string{"/c", "/b", "/a"}, keys)
+	assert.Empty(t, continuation)
+
+	r := <-client.List(context.Background(), "/a", "/g", Limit(0))
+	assert.ErrorIs(t, r.Err, ErrorInvalidOptionLimit)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_GetWithComparison(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NumShards = 4
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	for _, key := range []string{"/a", "/c", "/e", "/g", "/i"} {
+		_, err = client.Put(context.Background(), key, []byte(key))
+		assert.NoError(t, err)
+	}
+
+	for _, test := range []struct {
+		key         string
+		option      GetOption
+		expectedKey string
+	}{
+		{"/c", ComparisonEqual(), "/c"},
+		{"/c", ComparisonFloor(), "/c"},
+		{"/d", ComparisonFloor(), "/c"},
+		{"/c", ComparisonLower(), "/a"},
+		{"/c", ComparisonCeiling(), "/c"},
+		{"/d", ComparisonCeiling(), "/e"},
+		{"/c", ComparisonHigher(), "/e"},
+		{"/z", ComparisonFloor(), "/i"},
+	} {
+		value, version, err := client.Get(context.Background(), test.key, test.option)
+		assert.NoError(t, err, test)
+		assert.Equal(t, test.expectedKey, version.Key, test)
+		assert.Equal(t, test.expectedKey, string(value), test)
+	}
+
+	_, _, err = client.Get(context.Background(), "/a", ComparisonLower())
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	_, _, err = client.Get(context.Background(), "/i", ComparisonHigher())
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_GetWithComparisonPartialPermissions(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "tokens.csv")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("admin-token,admin\nreader-token,reader\n"), 0600))
+	authorizationFile := filepath.Join(dir, "authorization.yaml")
+	assert.NoError(t, os.WriteFile(authorizationFile, []byte(`
+roles:
+  - name: admin
+    principals: [admin]
+    rules:
+      - namespace: default
+        operations: [read, write]
+  - name: billing-reader
+    principals: [reader]
+    rules:
+      - namespace: default
+        prefix: /billing/
+        operations: [read]
+`), 0600))
+
+	config := server.NewTestConfig()
+	config.PublicServerAuth = security.AuthenticationOptions{Provider: security.AuthenticationProviderToken, TokenFile: tokenFile}
+	config.PublicServerAuthorizationFile = authorizationFile
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	serviceAddress := fmt.Sprintf("localhost:%d", standalone.RpcPort())
+	admin, err := NewSyncClient(serviceAddress, WithAuthentication(NewTokenAuthentication("admin-token")))
+	assert.NoError(t, err)
+	reader, err := NewSyncClient(serviceAddress, WithAuthentication(NewTokenAuthentication("reader-token")))
+	assert.NoError(t, err)
+
+	for _, key := range []string{"/billing/a", "/other/b"} {
+		_, err = admin.Put(context.Background(), key, []byte(key))
+		assert.NoError(t, err)
+	}
+
+	_, version, err := admin.Get(context.Background(), "/other/c", ComparisonFloor())
+	assert.NoError(t, err)
+	assert.Equal(t, "/other/b", version.Key)
+
+	// The forbidden "/other/b" is the floor of "/other/c", before the readable
+	// "/billing/a", so the lookup is denied rather than reported as not found
+	_, _, err = reader.Get(context.Background(), "/other/c", ComparisonFloor())
+	assert.ErrorIs(t, err, ErrorPermissionDenied)
+
+	_, _, err = reader.Get(context.Background(), "/billing/a", ComparisonCeiling())
+	assert.ErrorIs(t, err, ErrorPermissionDenied)
+
+	value, _, err := reader.Get(context.Background(), "/billing/a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("/billing/a"), value)
+
+	assert.NoError(t, admin.Close())
+	assert.NoError(t, reader.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_NotificationsFilter(t *testing.T) {
+	standalone, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	notifications, err := client.GetNotifications(FilterKeyPrefix("/a/"), FilterKeyPrefix("/c/"))
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = client.Put(ctx, "/b/1", []byte("0"))
+	s1, _ := client.Put(ctx, "/a/1", []byte("0"))
+	_, _ = client.Put(ctx, "/b/2", []byte("0"))
+	s2, _ := client.Put(ctx, "/c/1", []byte("0"))
+
+	n := <-notifications.Ch()
+	assert.Equal(t, KeyCreated, n.Type)
+	assert.Equal(t, "/a/1", n.Key)
+	assert.Equal(t, s1.VersionId, n.VersionId)
+
+	n = <-notifications.Ch()
+	assert.Equal(t, KeyCreated, n.Type)
+	assert.Equal(t, "/c/1", n.Key)
+	assert.Equal(t, s2.VersionId, n.VersionId)
+
+	select {
+	case n := <-notifications.Ch():
+		assert.Fail(t, "shouldn't have received any notifications", n)
+	case <-time.After(100 * time.Millisecond):
+		// Ok, we expect it to time out
+	}
+
+	assert.NoError(t, notifications.Close())
+
+	notifications, err = client.GetNotifications(FilterKeyRange("/b/2", "/c/"))
+	assert.NoError(t, err)
+
+	_, _ = client.Put(ctx, "/b/1", []byte("1"))
+	s3, _ := client.Put(ctx, "/b/3", []byte("1"))
+
+	n = <-notifications.Ch()
+	assert.Equal(t, KeyCreated, n.Type)
+	assert.Equal(t, "/b/3", n.Key)
+	assert.Equal(t, s3.VersionId, n.VersionId)
+
+	assert.NoError(t, notifications.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_NotificationsWithValues(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NotificationsIncludeValues = true
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	notifications, err := client.GetNotifications(IncludeValues(), IncludePreviousVersion())
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	s1, _ := client.Put(ctx, "/a", []byte("0"))
+	s2, _ := client.Put(ctx, "/a", []byte("1"))
+	assert.NoError(t, client.Delete(ctx, "/a"))
+
+	n := <-notifications.Ch()
+	assert.Equal(t, KeyCreated, n.Type)
+	assert.Equal(t, "0", string(n.Value))
+	assert.EqualValues(t, -1, n.PreviousVersionId)
+
+	n = <-notifications.Ch()
+	assert.Equal(t, KeyModified, n.Type)
+	assert.Equal(t, s2.VersionId, n.VersionId)
+	assert.Equal(t, "1", string(n.Value))
+	assert.Equal(t, s1.VersionId, n.PreviousVersionId)
+
+	n = <-notifications.Ch()
+	assert.Equal(t, KeyDeleted, n.Type)
+	assert.Nil(t, n.Value)
+	assert.Equal(t, s2.VersionId, n.PreviousVersionId)
+
+	assert.NoError(t, notifications.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_NotificationsResume(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NotificationsRetentionTime = 1 * time.Hour
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	notifications, err := client.GetNotifications()
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	_, _ = client.Put(ctx, "/a", []byte("0"))
+	_, _ = client.Put(ctx, "/b", []byte("0"))
+
+	n := <-notifications.Ch()
+	assert.Equal(t, "/a", n.Key)
+	assert.True(t, n.LastInBatch)
+	cursor := map[int64]int64{n.Shard: n.Offset}
+	assert.NoError(t, notifications.Close())
+	assert.NoError(t, notifications.Err())
+
+	_, _ = client.Put(ctx, "/c", []byte("0"))
+
+	// Restart after the last processed notification
+	notifications, err = client.GetNotifications(ResumeFrom(cursor))
+	assert.NoError(t, err)
+
+	n = <-notifications.Ch()
+	assert.Equal(t, "/b", n.Key)
+	assert.Equal(t, cursor[n.Shard]+1, n.Offset)
+
+	n = <-notifications.Ch()
+	assert.Equal(t, "/c", n.Key)
+	assert.Equal(t, cursor[n.Shard]+2, n.Offset)
+
+	assert.NoError(t, notifications.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_NotificationsResumeBatch(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NotificationsRetentionTime = 1 * time.Hour
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	notifications, err := client.GetNotifications()
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = client.Txn().
+		Put("/a", []byte("0")).
+		Put("/b", []byte("0")).
+		Commit(ctx)
+	assert.NoError(t, err)
+	_, _ = client.Put(ctx, "/c", []byte("0"))
+
+	// The changes of the same write batch share the offset, and only the last
+	// one is marked
+	n1 := <-notifications.Ch()
+	n2 := <-notifications.Ch()
+	assert.ElementsMatch(t, []string{"/a", "/b"}, []string{n1.Key, n2.Key})
+	assert.Equal(t, n1.Offset, n2.Offset)
+	assert.False(t, n1.LastInBatch)
+	assert.True(t, n2.LastInBatch)
+
+	n3 := <-notifications.Ch()
+	assert.Equal(t, "/c", n3.Key)
+	assert.Equal(t, n2.Offset+1, n3.Offset)
+	assert.True(t, n3.LastInBatch)
+	assert.NoError(t, notifications.Close())
+
+	// Resuming after the batch skips all of its changes
+	notifications, err = client.GetNotifications(ResumeFrom(map[int64]int64{n2.Shard: n2.Offset}))
+	assert.NoError(t, err)
+
+	n := <-notifications.Ch()
+	assert.Equal(t, "/c", n.Key)
+
+	assert.NoError(t, notifications.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
+
+func TestSyncClientImpl_NotificationsTrimmed(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NotificationsRetentionTime = 10 * time.Millisecond
+	standalone, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+
+	client, err := NewSyncClient(fmt.Sprintf("localhost:%d", standalone.RpcPort()))
+	assert.NoError(t, err)
+
+	version, err := client.Put(context.Background(), "/a", []byte("0"))
+	assert.NoError(t, err)
+
+	// Resume from before the first write, once it was discarded
+	assert.Eventually(t, func() bool {
+		notifications, err := client.GetNotifications(ResumeFrom(map[int64]int64{0: version.VersionId - 1}))
+		assert.NoError(t, err)
+
+		select {
+		case n, more := <-notifications.Ch():
+			if more {
+				assert.Equal(t, "/a", n.Key)
+				assert.NoError(t, notifications.Close())
+				return false
+			}
+		case <-time.After(1 * time.Second):
+			assert.Fail(t, "the channel should have been closed")
+		}
+		return errors.Is(notifications.Err(), ErrorNotificationsTrimmed)
+	}, 10*time.Second, 100*time.Millisecond)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standalone.Close())
+}
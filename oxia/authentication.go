@@ -0,0 +1,62 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"oxia/common/security"
+)
+
+// Authentication provides the credentials that the client attaches to all its
+// calls to the Oxia servers.
+type Authentication interface {
+	credentials.PerRPCCredentials
+}
+
+type tokenAuthentication struct {
+	tokenProvider func() (string, error)
+}
+
+// NewTokenAuthentication creates an Authentication that presents the token
+// as a bearer token. The token is not encrypted by the client: [WithTLS] should
+// be used for it not to be sent in clear over the network.
+func NewTokenAuthentication(token string) Authentication {
+	return NewTokenProviderAuthentication(func() (string, error) {
+		return token, nil
+	})
+}
+
+// NewTokenProviderAuthentication creates an Authentication that presents the
+// bearer token returned by the provider, which is called for every request and
+// stream. It can be used to refresh short-lived tokens.
+func NewTokenProviderAuthentication(tokenProvider func() (string, error)) Authentication {
+	return &tokenAuthentication{tokenProvider: tokenProvider}
+}
+
+func (a *tokenAuthentication) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token, err := a.tokenProvider()
+	if err != nil {
+		return nil, errors.Wrap(err, "oxia: failed to get the authentication token")
+	}
+	return map[string]string{
+		security.MetadataAuthorization: security.BearerPrefix + token,
+	}, nil
+}
+
+func (a *tokenAuthentication) RequireTransportSecurity() bool {
+	return false
+}
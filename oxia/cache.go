@@ -17,14 +17,16 @@ package oxia
 import (
 	"context"
 	"github.com/cenkalti/backoff/v4"
-	"github.com/dgraph-io/ristretto"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/multierr"
 	"io"
 	"oxia/common"
+	"oxia/oxia/internal/metrics"
+	"strings"
 	"sync"
-	"time"
 )
 
 // Cache provides a view of the data stored in Oxia that is locally cached.
@@ -81,40 +83,50 @@ type DeserializeFunc func(data []byte, value any) error
 
 // NewCache creates a new cache object for a specific type
 // Uses the `serializeFunc` and `deserializeFunc` for SerDe
-func NewCache[T any](client SyncClient, serializeFunc SerializeFunc, deserializeFunc DeserializeFunc) (Cache[T], error) {
+//
+// A list of CacheOption arguments can be passed to configure the size limits
+// and the expiration of the cached records.
+// Example:
+//
+//	cache, err := oxia.NewCache[MyType](client, json.Marshal, json.Unmarshal, oxia.WithCacheMaxEntries(1000))
+func NewCache[T any](client SyncClient, serializeFunc SerializeFunc, deserializeFunc DeserializeFunc,
+	opts ...CacheOption) (Cache[T], error) {
 	c, ok := client.(*syncClientImpl)
 	if !ok {
 		return nil, errors.New("Invalid client implementation")
 	}
 
+	options, err := newCacheOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	cm, err := c.getCacheManager()
 	if err != nil {
 		return nil, err
 	}
 
-	return newCache[T](cm, serializeFunc, deserializeFunc)
+	return newCache[T](cm, serializeFunc, deserializeFunc, options)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-const (
-	defaultCacheTTL = 5 * time.Minute
-)
-
 type cacheManager struct {
 	sync.Mutex
 
 	client        SyncClient
 	notifications Notifications
 	caches        []internalCache
+	metrics       *metrics.CacheMetrics
 
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
-func newCacheManager(client SyncClient) (*cacheManager, error) {
+func newCacheManager(client SyncClient, meterProvider metric.MeterProvider) (*cacheManager, error) {
 	cm := &cacheManager{
-		client: client,
+		client:  client,
+		metrics: metrics.NewCacheMetrics(meterProvider),
 	}
 
 	cm.ctx, cm.cancel = context.WithCancel(context.Background())
@@ -162,11 +174,12 @@ func (cm *cacheManager) handleNotification(n *Notification) {
 	}
 }
 
-func newCache[T any](cm *cacheManager, serializeFunc SerializeFunc, deserializeFunc DeserializeFunc) (Cache[T], error) {
+func newCache[T any](cm *cacheManager, serializeFunc SerializeFunc, deserializeFunc DeserializeFunc,
+	options cacheOptions) (Cache[T], error) {
 	cm.Lock()
 	defer cm.Unlock()
 
-	cache, err := newCacheImpl[T](cm.client, serializeFunc, deserializeFunc)
+	cache, err := newCacheImpl[T](cm.client, serializeFunc, deserializeFunc, options, cm.metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -197,38 +210,148 @@ type internalCache interface {
 }
 
 type cacheImpl[Value any] struct {
-	sync.RWMutex
+	sync.Mutex
 
 	client          SyncClient
 	serializeFunc   SerializeFunc
 	deserializeFunc DeserializeFunc
-	valueCache      *ristretto.Cache
+	options         cacheOptions
+	metrics         *metrics.CacheMetrics
+	valueCache      *lruCache[cachedResult[Value]]
+
+	// The records below the preload prefix, kept up to date by following
+	// the notifications for the prefix
+	preloaded     map[string]preloadedRecord[Value]
+	notifications Notifications
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	log    zerolog.Logger
 }
 
-func newCacheImpl[Value any](client SyncClient, serializeFunc SerializeFunc, deserializeFunc DeserializeFunc) (*cacheImpl[Value], error) {
+func newCacheImpl[Value any](client SyncClient, serializeFunc SerializeFunc, deserializeFunc DeserializeFunc,
+	options cacheOptions, cacheMetrics *metrics.CacheMetrics) (*cacheImpl[Value], error) {
 	c := &cacheImpl[Value]{
 		client:          client,
 		serializeFunc:   serializeFunc,
 		deserializeFunc: deserializeFunc,
+		options:         options,
+		metrics:         cacheMetrics,
+		log: log.Logger.With().
+			Str("component", "oxia-cache").
+			Logger(),
+	}
+
+	c.valueCache = newLruCache[cachedResult[Value]](options.maxEntries, options.maxBytes,
+		common.SystemClock, cacheMetrics.Evicted)
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	if options.preloadPrefix != nil {
+		if err := c.preload(*options.preloadPrefix); err != nil {
+			c.cancel()
+			return nil, err
+		}
 	}
+	return c, nil
+}
 
+// preload subscribes to the notifications for the prefix before reading all
+// its records, so that no change is missed between the two
+func (c *cacheImpl[Value]) preload(path string) error {
 	var err error
-	c.valueCache, err = ristretto.NewCache(&ristretto.Config{
-		NumCounters: 1_000_000,
-		MaxCost:     64 * 1024 * 1024,
-		BufferItems: 64,
-	})
+	c.notifications, err = c.client.GetNotifications(FilterKeyPrefix(path+"/"), IncludeValues())
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to create notifications client")
 	}
-	return c, nil
+
+	c.preloaded = make(map[string]preloadedRecord[Value])
+	minKeyInclusive, maxKeyExclusive := descendantsRange(path)
+	for r := range c.client.RangeScan(c.ctx, minKeyInclusive, maxKeyExclusive) {
+		if r.Err != nil {
+			return multierr.Append(errors.Wrap(r.Err, "failed to preload the cache"), c.notifications.Close())
+		}
+		c.preloaded[r.Version.Key] = c.newPreloadedRecord(r.Value, r.Version)
+	}
+
+	c.log = c.log.With().Str("prefix", path).Logger()
+	c.log.Debug().
+		Int("records", len(c.preloaded)).
+		Msg("Preloaded the cache")
+
+	go common.DoWithLabels(map[string]string{
+		"oxia": "cache-preload",
+	}, c.followNotifications)
+	return nil
+}
+
+func (c *cacheImpl[Value]) followNotifications() {
+	for n := range c.notifications.Ch() {
+		c.refresh(n)
+	}
+}
+
+// refresh updates the record that was changed with the value carried by the
+// notification, unless the preloaded version is already as recent. The record
+// is read only when the notification has no value
+func (c *cacheImpl[Value]) refresh(n *Notification) {
+	c.Lock()
+	existing, ok := c.preloaded[n.Key]
+	if n.Type != KeyDeleted && ok && existing.version.VersionId >= n.VersionId {
+		c.Unlock()
+		return
+	}
+	if n.Type != KeyDeleted && n.Value != nil {
+		c.storePreloaded(c.newPreloadedRecord(n.Value, Version{Key: n.Key, VersionId: n.VersionId}))
+		c.Unlock()
+		return
+	}
+	c.Unlock()
+
+	data, version, err := c.client.Get(c.ctx, n.Key)
+
+	c.Lock()
+	defer c.Unlock()
+
+	switch {
+	case errors.Is(err, ErrorKeyNotFound):
+		delete(c.preloaded, n.Key)
+	case c.ctx.Err() != nil:
+		// The cache was closed
+	case err != nil:
+		c.log.Warn().Err(err).
+			Str("key", n.Key).
+			Msg("Failed to refresh the preloaded record")
+	default:
+		c.storePreloaded(c.newPreloadedRecord(data, version))
+	}
+}
+
+func (c *cacheImpl[Value]) newPreloadedRecord(data []byte, version Version) preloadedRecord[Value] {
+	r := preloadedRecord[Value]{version: version}
+	if err := c.deserializeFunc(data, &r.value); err != nil {
+		r.err = errors.Wrap(err, "failed to deserialize value")
+	}
+	return r
+}
+
+// storePreloaded replaces the preloaded record, unless the existing one is
+// more recent
+func (c *cacheImpl[Value]) storePreloaded(r preloadedRecord[Value]) {
+	if existing, ok := c.preloaded[r.version.Key]; ok && existing.version.VersionId > r.version.VersionId {
+		return
+	}
+	c.preloaded[r.version.Key] = r
+}
+
+func (c *cacheImpl[Value]) isPreloaded(key string) bool {
+	return c.options.preloadPrefix != nil && strings.HasPrefix(key, *c.options.preloadPrefix+"/")
 }
 
 func (c *cacheImpl[Value]) handleNotification(n *Notification) {
-	c.RLock()
-	defer c.RUnlock()
+	c.Lock()
+	defer c.Unlock()
 
-	c.valueCache.Del(n.Key)
+	c.valueCache.remove(n.Key)
 }
 
 func (c *cacheImpl[Value]) Put(ctx context.Context, key string, value Value, options ...PutOption) (Version, error) {
@@ -238,8 +361,14 @@ func (c *cacheImpl[Value]) Put(ctx context.Context, key string, value Value, opt
 	}
 
 	version, err := c.client.Put(ctx, key, data, options...)
-	if !errors.Is(err, ErrorUnexpectedVersionId) {
-		c.valueCache.Del(key)
+
+	c.Lock()
+	defer c.Unlock()
+
+	if err == nil && c.isPreloaded(key) {
+		c.storePreloaded(preloadedRecord[Value]{value: value, version: version})
+	} else if !errors.Is(err, ErrorUnexpectedVersionId) {
+		c.valueCache.remove(key)
 	}
 
 	return version, err
@@ -247,27 +376,52 @@ func (c *cacheImpl[Value]) Put(ctx context.Context, key string, value Value, opt
 
 func (c *cacheImpl[Value]) Delete(ctx context.Context, key string, options ...DeleteOption) error {
 	err := c.client.Delete(ctx, key, options...)
-	c.valueCache.Del(key)
+
+	c.Lock()
+	defer c.Unlock()
+
+	if err == nil && c.isPreloaded(key) {
+		delete(c.preloaded, key)
+	}
+	c.valueCache.remove(key)
 	return err
 }
 
 func (c *cacheImpl[Value]) Get(ctx context.Context, key string) (value Value, version Version, err error) {
-	if cachedValue, cached := c.valueCache.Get(key); cached {
-		if cv, present := cachedValue.(cachedResult[Value]).Get(); present {
+	c.Lock()
+	if c.isPreloaded(key) {
+		defer c.Unlock()
+		c.metrics.Hit()
+		if r, ok := c.preloaded[key]; ok {
+			return r.value, r.version, r.err
+		}
+		return value, version, ErrorKeyNotFound
+	}
+
+	cachedValue, cached := c.valueCache.get(key)
+	c.Unlock()
+
+	if cached {
+		c.metrics.Hit()
+		if cv, present := cachedValue.Get(); present {
 			return cv.value, cv.version, nil
 		} else {
 			return value, version, ErrorKeyNotFound
 		}
 	}
 
+	c.metrics.Miss()
 	return c.load(ctx, key)
 }
 
 func (c *cacheImpl[Value]) load(ctx context.Context, key string) (value Value, version Version, err error) {
 	data, existingVersion, err := c.client.Get(ctx, key)
 	if err == ErrorKeyNotFound {
-		cr := empty[cachedResult[Value]]()
-		c.valueCache.Set(key, cr, 0)
+		if c.options.negativeTTL > 0 {
+			c.Lock()
+			c.valueCache.put(key, cachedResult[Value](empty[valueVersion[Value]]()), 0, c.options.negativeTTL)
+			c.Unlock()
+		}
 		return value, version, err
 	}
 
@@ -284,7 +438,9 @@ func (c *cacheImpl[Value]) load(ctx context.Context, key string) (value Value, v
 		version: existingVersion,
 	})
 
-	c.valueCache.SetWithTTL(key, cr, int64(len(data)), defaultCacheTTL)
+	c.Lock()
+	c.valueCache.put(key, cachedResult[Value](cr), int64(len(data)), c.options.ttl)
+	c.Unlock()
 	return value, existingVersion, nil
 }
 
@@ -324,11 +480,18 @@ func (c *cacheImpl[Value]) ReadModifyUpdate(ctx context.Context, key string, mod
 }
 
 func (c *cacheImpl[Value]) Close() error {
+	c.cancel()
+
+	var err error
+	if c.notifications != nil {
+		err = c.notifications.Close()
+	}
+
 	c.Lock()
 	defer c.Unlock()
 
-	c.valueCache.Close()
-	return nil
+	c.valueCache.clear()
+	return err
 }
 
 ////////////////////////////////
@@ -339,3 +502,9 @@ type valueVersion[Value any] struct {
 	value   Value
 	version Version
 }
+
+type preloadedRecord[Value any] struct {
+	value   Value
+	version Version
+	err     error
+}
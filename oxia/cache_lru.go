@@ -0,0 +1,126 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"container/list"
+	"oxia/common"
+	"time"
+)
+
+// lruCache holds the entries of a [Cache], up to a maximum number of entries
+// and up to a maximum total size. When either limit is exceeded, the least
+// recently used entries are evicted. Each entry also expires after its TTL.
+//
+// lruCache is not thread-safe.
+type lruCache[V any] struct {
+	maxEntries int
+	maxBytes   int64
+	clock      common.Clock
+	onEvict    func()
+
+	entries map[string]*list.Element
+	// order has the most recently used entry at the front
+	order *list.List
+	size  int64
+}
+
+type lruEntry[V any] struct {
+	key        string
+	value      V
+	size       int64
+	expiration time.Time
+}
+
+func newLruCache[V any](maxEntries int, maxBytes int64, clock common.Clock, onEvict func()) *lruCache[V] {
+	return &lruCache[V]{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		clock:      clock,
+		onEvict:    onEvict,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// get returns the value of the key, if present and not expired, and marks it
+// as the most recently used
+func (c *lruCache[V]) get(key string) (value V, found bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return value, false
+	}
+
+	entry := element.Value.(*lruEntry[V])
+	if c.expired(entry) {
+		c.removeElement(element)
+		return value, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// put adds or replaces the value of the key, and evicts the least recently
+// used entries until the cache is within its limits
+func (c *lruCache[V]) put(key string, value V, size int64, ttl time.Duration) {
+	c.remove(key)
+	if size > c.maxBytes {
+		// Storing the entry would evict everything else, and still exceed the limit
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{
+		key:        key,
+		value:      value,
+		size:       size,
+		expiration: c.clock.Now().Add(ttl),
+	})
+	c.size += size
+
+	for len(c.entries) > c.maxEntries || c.size > c.maxBytes {
+		oldest := c.order.Back()
+		if !c.expired(oldest.Value.(*lruEntry[V])) {
+			c.onEvict()
+		}
+		c.removeElement(oldest)
+	}
+}
+
+func (c *lruCache[V]) remove(key string) {
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+func (c *lruCache[V]) clear() {
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.size = 0
+}
+
+func (c *lruCache[V]) len() int {
+	return len(c.entries)
+}
+
+func (c *lruCache[V]) removeElement(element *list.Element) {
+	entry := c.order.Remove(element).(*lruEntry[V])
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+func (c *lruCache[V]) expired(entry *lruEntry[V]) bool {
+	return !c.clock.Now().Before(entry.expiration)
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oxia/common"
+	"testing"
+	"time"
+)
+
+func TestLruCache_MaxEntries(t *testing.T) {
+	evictions := 0
+	c := newLruCache[string](2, 1000, common.SystemClock, func() { evictions++ })
+
+	c.put("a", "value-a", 10, time.Hour)
+	c.put("b", "value-b", 10, time.Hour)
+
+	// Reading "a" makes "b" the least recently used
+	value, found := c.get("a")
+	assert.True(t, found)
+	assert.Equal(t, "value-a", value)
+
+	c.put("c", "value-c", 10, time.Hour)
+	assert.Equal(t, 2, c.len())
+	assert.Equal(t, 1, evictions)
+
+	_, found = c.get("b")
+	assert.False(t, found)
+	_, found = c.get("a")
+	assert.True(t, found)
+	_, found = c.get("c")
+	assert.True(t, found)
+}
+
+func TestLruCache_MaxBytes(t *testing.T) {
+	evictions := 0
+	c := newLruCache[string](100, 30, common.SystemClock, func() { evictions++ })
+
+	c.put("a", "value-a", 10, time.Hour)
+	c.put("b", "value-b", 10, time.Hour)
+	c.put("c", "value-c", 10, time.Hour)
+	assert.Equal(t, 3, c.len())
+	assert.EqualValues(t, 30, c.size)
+
+	c.put("d", "value-d", 20, time.Hour)
+	assert.Equal(t, 2, c.len())
+	assert.EqualValues(t, 30, c.size)
+	assert.Equal(t, 2, evictions)
+
+	// Replacing an entry accounts for the new size
+	c.put("d", "value-d", 5, time.Hour)
+	assert.EqualValues(t, 15, c.size)
+
+	// An entry that exceeds the limit by itself is not stored
+	c.put("e", "value-e", 31, time.Hour)
+	_, found := c.get("e")
+	assert.False(t, found)
+	assert.Equal(t, 2, c.len())
+	assert.Equal(t, 2, evictions)
+
+	c.remove("c")
+	c.remove("d")
+	assert.Equal(t, 0, c.len())
+	assert.EqualValues(t, 0, c.size)
+}
+
+func TestLruCache_Expiration(t *testing.T) {
+	clock := &common.MockedClock{}
+	evictions := 0
+	c := newLruCache[string](2, 1000, clock, func() { evictions++ })
+
+	clock.Set(1000)
+	c.put("a", "value-a", 10, 1*time.Second)
+	c.put("b", "value-b", 10, 5*time.Second)
+
+	clock.Set(1999)
+	_, found := c.get("a")
+	assert.True(t, found)
+
+	clock.Set(2000)
+	_, found = c.get("a")
+	assert.False(t, found)
+	assert.Equal(t, 1, c.len())
+
+	// The expired entries that are pushed out don't count as evictions
+	c.put("c", "value-c", 10, 1*time.Second)
+	_, found = c.get("b")
+	assert.True(t, found)
+	clock.Set(3000)
+	c.put("d", "value-d", 10, 1*time.Second)
+	assert.Equal(t, 0, evictions)
+
+	_, found = c.get("b")
+	assert.True(t, found)
+	_, found = c.get("d")
+	assert.True(t, found)
+
+	c.clear()
+	assert.Equal(t, 0, c.len())
+	assert.EqualValues(t, 0, c.size)
+}
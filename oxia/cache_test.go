@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"oxia/common"
@@ -36,7 +37,9 @@ var standalone *server.Standalone
 var serviceAddress string
 
 func TestMain(m *testing.M) {
-	standalone, _ = server.NewStandalone(server.NewTestConfig())
+	config := server.NewTestConfig()
+	config.NotificationsIncludeValues = true
+	standalone, _ = server.NewStandalone(config)
 	defer standalone.Close()
 	serviceAddress = fmt.Sprintf("localhost:%d", standalone.RpcPort())
 
@@ -249,3 +252,87 @@ func TestCache_ConcurrentUpdate(t *testing.T) {
 	assert.NoError(t, cache2.Close())
 	assert.NoError(t, client2.Close())
 }
+
+func TestCache_PreloadPrefix(t *testing.T) {
+	client, err := NewSyncClient(serviceAddress)
+	assert.NoError(t, err)
+
+	prefix := newKey()
+	v1 := testStruct{"hello", 1}
+	data, err := json.Marshal(v1)
+	assert.NoError(t, err)
+	_, err = client.Put(context.Background(), prefix+"/a", data)
+	assert.NoError(t, err)
+	_, err = client.Put(context.Background(), prefix+"/b/c", data)
+	assert.NoError(t, err)
+
+	cache, err := NewCache[testStruct](client, json.Marshal, json.Unmarshal, WithCachePreloadPrefix(prefix))
+	assert.NoError(t, err)
+
+	value, _, err := cache.Get(context.Background(), prefix+"/a")
+	assert.NoError(t, err)
+	assert.Equal(t, v1, value)
+
+	value, _, err = cache.Get(context.Background(), prefix+"/b/c")
+	assert.NoError(t, err)
+	assert.Equal(t, v1, value)
+
+	_, _, err = cache.Get(context.Background(), prefix+"/d")
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	// The changes applied outside the cache are picked up from the notifications
+	v2 := testStruct{"hello", 2}
+	data, err = json.Marshal(v2)
+	assert.NoError(t, err)
+	_, err = client.Put(context.Background(), prefix+"/d", data)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Delete(context.Background(), prefix+"/a"))
+
+	assert.Eventually(t, func() bool {
+		value, _, err := cache.Get(context.Background(), prefix+"/d")
+		return err == nil && value == v2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The record was taken from the notification, without reading it
+	_, version, err := cache.Get(context.Background(), prefix+"/d")
+	assert.NoError(t, err)
+	assert.Equal(t, prefix+"/d", version.Key)
+	assert.Zero(t, version.CreatedTimestamp)
+
+	assert.Eventually(t, func() bool {
+		_, _, err := cache.Get(context.Background(), prefix+"/a")
+		return errors.Is(err, ErrorKeyNotFound)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The writes through the cache are visible right away
+	version, err = cache.Put(context.Background(), prefix+"/e", v1)
+	assert.NoError(t, err)
+	value, version2, err := cache.Get(context.Background(), prefix+"/e")
+	assert.NoError(t, err)
+	assert.Equal(t, v1, value)
+	assert.Equal(t, version, version2)
+
+	assert.NoError(t, cache.Close())
+	assert.NoError(t, client.Close())
+}
+
+func TestCache_NegativeTTL(t *testing.T) {
+	client, err := NewSyncClient(serviceAddress)
+	assert.NoError(t, err)
+
+	cache, err := NewCache[testStruct](client, json.Marshal, json.Unmarshal,
+		WithCacheNegativeTTL(0), WithCacheMaxEntries(10))
+	assert.NoError(t, err)
+
+	k1 := newKey()
+	_, _, err = cache.Get(context.Background(), k1)
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	c := cache.(*cacheImpl[testStruct])
+	c.Lock()
+	assert.Equal(t, 0, c.valueCache.len())
+	c.Unlock()
+
+	assert.NoError(t, cache.Close())
+	assert.NoError(t, client.Close())
+}
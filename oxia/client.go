@@ -37,6 +37,34 @@ var (
 	// ErrorRequestTooLarge is returned when a request is larger than the maximum batch size
 	ErrorRequestTooLarge = batch.ErrorRequestTooLarge
 
+	// ErrorTransactionAborted The operation was not applied because another operation
+	// in the same transaction has failed
+	ErrorTransactionAborted = errors.New("transaction aborted")
+
+	// ErrorTransactionSpansShards The records in a transaction are not all stored in
+	// the same shard
+	ErrorTransactionSpansShards = errors.New("transaction records belong to multiple shards")
+
+	// ErrorInvalidSequentialKeys The [SequentialKeysDeltas] option requires a [PartitionKey],
+	// a first delta greater than zero and cannot be combined with [ExpectedVersionId]
+	ErrorInvalidSequentialKeys = errors.New("invalid sequential keys options")
+
+	// ErrorNotificationsTrimmed The notifications cannot be resumed from the requested
+	// offsets, because the servers have already discarded them after their retention time
+	ErrorNotificationsTrimmed = errors.New("notifications offset was already trimmed")
+
+	// ErrorInvalidValue The current value of the record cannot be combined with the
+	// atomic operation, for instance because it is not a decimal number in [SyncClient.Increment]
+	ErrorInvalidValue = errors.New("invalid value for the operation")
+
+	// ErrorPermissionDenied The principal of the client is not allowed to access the key
+	// of the operation, by the authorization rules of the namespace
+	ErrorPermissionDenied = errors.New("permission denied")
+
+	// ErrorInvalidKey The key of the operation is reserved for the internal state of the
+	// shards, as are all the keys starting with `__oxia/`
+	ErrorInvalidKey = errors.New("invalid key")
+
 	// ErrorUnknownStatus Unknown error
 	ErrorUnknownStatus = errors.New("unknown status")
 )
@@ -62,16 +90,38 @@ type AsyncClient interface {
 	//    a specific existing version by passing the [ExpectedVersionId] option.
 	//  - Client can assert that the record does not exist by passing [ExpectedRecordNotExists]
 	//  - Client can create an ephemeral record with [Ephemeral]
+	//  - Client can select the shard where the record is stored with [PartitionKey]
+	//  - Client can let the server generate a unique key with [SequentialKeysDeltas]
+	//  - Client can set a time to live for the record with [WithTTL]
+	//  - Client can read the record that was replaced with [ReturnPrevious]
 	//
 	// Returns a [Version] object that contains information about the newly updated record
 	// Returns [ErrorUnexpectedVersionId] if the expected version id does not match the
 	// current version id of the record
 	Put(key string, value []byte, options ...PutOption) <-chan PutResult
 
+	// Increment atomically adds a delta to the value of a record, which is stored as
+	// a decimal int64. A record that does not exist counts as 0.
+	//
+	// The operation is evaluated by the server, so that concurrent increments never
+	// conflict. It supports the same options as [AsyncClient.Put].
+	// Returns [ErrorInvalidValue] if the current value is not a decimal int64, or if
+	// the result would overflow
+	Increment(key string, delta int64, options ...PutOption) <-chan IncrementResult
+
+	// Append atomically appends a value to the current value of a record. A record
+	// that does not exist counts as empty.
+	//
+	// The operation is evaluated by the server, so that concurrent appends never
+	// conflict. It supports the same options as [AsyncClient.Put]
+	Append(key string, value []byte, options ...PutOption) <-chan AppendResult
+
 	// Delete removes the key and its associated value from the data store.
 	//
 	// The Delete operation can be made conditional on that the record hasn't changed from
 	// a specific existing version by passing the [ExpectedVersionId] option.
+	// If the record was stored with a [PartitionKey], the same option must be passed.
+	// The deleted record can be read with [ReturnPrevious].
 	// Returns [ErrorUnexpectedVersionId] if the expected version id does not match the
 	// current version id of the record
 	Delete(key string, options ...DeleteOption) <-chan error
@@ -82,21 +132,82 @@ type AsyncClient interface {
 	// https://github.com/streamnative/oxia/blob/main/docs/oxia-key-sorting.md
 	DeleteRange(minKeyInclusive string, maxKeyExclusive string) <-chan error
 
+	// DeleteRecursive deletes the record of the path, if it exists, and all the
+	// records below it, at any depth. For example, deleting `/xyz` removes `/xyz`,
+	// `/xyz/A` and `/xyz/A/1`, but not `/xyz-1`.
+	// A notification is emitted for each deleted record
+	DeleteRecursive(path string) <-chan error
+
 	// Get returns the value associated with the specified key.
 	// In addition to the value, a version object is also returned, with information
 	// about the record state.
+	// If the record was stored with a [PartitionKey], the same option must be passed.
+	// With [ComparisonFloor], [ComparisonCeiling], [ComparisonLower] or [ComparisonHigher],
+	// the closest record is returned instead, and its key is available in [Version.Key].
+	// Returns ErrorKeyNotFound if the record does not exist
+	Get(key string, options ...GetOption) <-chan GetResult
+
+	// Stat returns the version information of the specified key, without
+	// transferring its value. It supports the same options as [AsyncClient.Get],
+	// and the [GetResult.Value] is always nil.
 	// Returns ErrorKeyNotFound if the record does not exist
-	Get(key string) <-chan GetResult
+	Stat(key string, options ...GetOption) <-chan GetResult
+
+	// Exists checks whether the specified key exists, without transferring its
+	// value. It supports the same options as [AsyncClient.Get]
+	Exists(key string, options ...GetOption) <-chan ExistsResult
 
 	// List any existing keys within the specified range.
 	// Note: Oxia uses a custom sorting order that treats `/` characters in special way.
 	// Refer to this documentation for the specifics:
 	// https://github.com/streamnative/oxia/blob/main/docs/oxia-key-sorting.md
-	List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan ListResult
+	// With the [PartitionKey] option, only the shard of the partition key is queried.
+	// The keys are returned in order, across all the shards. The [Limit], [Reverse]
+	// and [ContinueFrom] options make it possible to page through the range.
+	List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string, options ...ListOption) <-chan ListResult
+
+	// ListChildren lists the keys of the direct children of the path, that is the
+	// keys made of the path, a `/` and one more segment. For example, the children
+	// of `/xyz` are `/xyz/A` and `/xyz/B`, but not `/xyz/A/1`.
+	// It supports the same options as [AsyncClient.List]
+	ListChildren(ctx context.Context, path string, options ...ListOption) <-chan ListResult
+
+	// RangeScan returns all the records, with their values and versions, within
+	// the specified range. The records are returned in the order of their keys.
+	// The key of each record is available in [Version.Key]. If an error occurs,
+	// a result with the error is returned and the channel gets closed.
+	// Note: Oxia uses a custom sorting order that treats `/` characters in special way.
+	// Refer to this documentation for the specifics:
+	// https://github.com/streamnative/oxia/blob/main/docs/oxia-key-sorting.md
+	RangeScan(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan GetResult
+
+	// CountRange returns the number of records within the specified range, and
+	// the total size of their values. The records are counted by each shard, and
+	// the counts are aggregated by the client.
+	// Note: Oxia uses a custom sorting order that treats `/` characters in special way.
+	// Refer to this documentation for the specifics:
+	// https://github.com/streamnative/oxia/blob/main/docs/oxia-key-sorting.md
+	CountRange(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan CountRangeResult
 
 	// GetNotifications creates a new subscription to receive the notifications
-	// from Oxia for any change that is applied to the database
-	GetNotifications() (Notifications, error)
+	// from Oxia for any change that is applied to the database.
+	// The [FilterKeyPrefix] and [FilterKeyRange] options restrict the
+	// subscription to a subset of the keys. With [ResumeFrom], the subscription
+	// restarts from the offsets saved by a previous one.
+	GetNotifications(options ...NotificationsOption) (Notifications, error)
+
+	// SessionDone returns the channel that is closed once the session used for
+	// the ephemeral records of the partition key is lost.
+	// See [SyncClient.SessionDone] for the details.
+	SessionDone(partitionKey string) <-chan SessionDoneResult
+
+	// Txn starts a new transaction.
+	//
+	// The operations added to the transaction are applied atomically when it
+	// gets committed: either all of them are applied or none of them is.
+	// All the records in a transaction must be stored in the same shard, which can
+	// be ensured by passing the same [PartitionKey] to all the operations.
+	Txn() AsyncTxn
 }
 
 // SyncClient is the main interface to perform operations with Oxia.
@@ -117,16 +228,40 @@ type SyncClient interface {
 	//    a specific existing version by passing the [ExpectedVersionId] option.
 	//  - Client can assert that the record does not exist by passing [ExpectedRecordNotExists]
 	//  - Client can create an ephemeral record with [Ephemeral]
+	//  - Client can select the shard where the record is stored with [PartitionKey]
+	//  - Client can let the server generate a unique key with [SequentialKeysDeltas]
+	//  - Client can set a time to live for the record with [WithTTL]
+	//  - Client can read the record that was replaced with [ReturnPrevious]
 	//
 	// Returns a [Version] object that contains information about the newly updated record
 	// Returns [ErrorUnexpectedVersionId] if the expected version id does not match the
 	// current version id of the record
 	Put(ctx context.Context, key string, value []byte, options ...PutOption) (Version, error)
 
+	// Increment atomically adds a delta to the value of a record, which is stored as
+	// a decimal int64. A record that does not exist counts as 0.
+	//
+	// The operation is evaluated by the server, so that concurrent increments never
+	// conflict. It supports the same options as [SyncClient.Put].
+	// Returns the resulting value and the version of the record.
+	// Returns [ErrorInvalidValue] if the current value is not a decimal int64, or if
+	// the result would overflow
+	Increment(ctx context.Context, key string, delta int64, options ...PutOption) (int64, Version, error)
+
+	// Append atomically appends a value to the current value of a record. A record
+	// that does not exist counts as empty.
+	//
+	// The operation is evaluated by the server, so that concurrent appends never
+	// conflict. It supports the same options as [SyncClient.Put].
+	// Returns the resulting value and the version of the record
+	Append(ctx context.Context, key string, value []byte, options ...PutOption) ([]byte, Version, error)
+
 	// Delete removes the key and its associated value from the data store.
 	//
 	// The Delete operation can be made conditional on that the record hasn't changed from
 	// a specific existing version by passing the [ExpectedVersionId] option.
+	// If the record was stored with a [PartitionKey], the same option must be passed.
+	// The deleted record can be read with [ReturnPrevious].
 	// Returns [ErrorUnexpectedVersionId] if the expected version id does not match the
 	// current version id of the record
 	Delete(ctx context.Context, key string, options ...DeleteOption) error
@@ -137,25 +272,142 @@ type SyncClient interface {
 	// https://github.com/streamnative/oxia/blob/main/docs/oxia-key-sorting.md
 	DeleteRange(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) error
 
+	// DeleteRecursive deletes the record of the path, if it exists, and all the
+	// records below it, at any depth. For example, deleting `/xyz` removes `/xyz`,
+	// `/xyz/A` and `/xyz/A/1`, but not `/xyz-1`.
+	// A notification is emitted for each deleted record
+	DeleteRecursive(ctx context.Context, path string) error
+
 	// Get returns the value associated with the specified key.
 	// In addition to the value, a version object is also returned, with information
 	// about the record state.
+	// If the record was stored with a [PartitionKey], the same option must be passed.
+	// With [ComparisonFloor], [ComparisonCeiling], [ComparisonLower] or [ComparisonHigher],
+	// the closest record is returned instead, and its key is available in [Version.Key].
+	// Returns ErrorKeyNotFound if the record does not exist
+	Get(ctx context.Context, key string, options ...GetOption) (value []byte, version Version, err error)
+
+	// Stat returns the version information of the specified key, without
+	// transferring its value. It supports the same options as [SyncClient.Get].
 	// Returns ErrorKeyNotFound if the record does not exist
-	Get(ctx context.Context, key string) (value []byte, version Version, err error)
+	Stat(ctx context.Context, key string, options ...GetOption) (Version, error)
+
+	// Exists checks whether the specified key exists, without transferring its
+	// value. It supports the same options as [SyncClient.Get]
+	Exists(ctx context.Context, key string, options ...GetOption) (bool, error)
 
 	// List any existing keys within the specified range.
 	// Note: Oxia uses a custom sorting order that treats `/` characters in special way.
 	// Refer to this documentation for the specifics:
 	// https://github.com/streamnative/oxia/blob/main/docs/oxia-key-sorting.md
-	List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan ListResult
+	// With the [PartitionKey] option, only the shard of the partition key is queried.
+	// The keys are returned in order, across all the shards. The [Limit], [Reverse]
+	// and [ContinueFrom] options make it possible to page through the range.
+	List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string, options ...ListOption) <-chan ListResult
+
+	// ListChildren lists the keys of the direct children of the path, that is the
+	// keys made of the path, a `/` and one more segment. For example, the children
+	// of `/xyz` are `/xyz/A` and `/xyz/B`, but not `/xyz/A/1`.
+	// It supports the same options as [SyncClient.List]
+	ListChildren(ctx context.Context, path string, options ...ListOption) <-chan ListResult
+
+	// RangeScan returns all the records, with their values and versions, within
+	// the specified range. The records are returned in the order of their keys.
+	// The key of each record is available in [Version.Key]. If an error occurs,
+	// a result with the error is returned and the channel gets closed.
+	// Note: Oxia uses a custom sorting order that treats `/` characters in special way.
+	// Refer to this documentation for the specifics:
+	// https://github.com/streamnative/oxia/blob/main/docs/oxia-key-sorting.md
+	RangeScan(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan GetResult
+
+	// CountRange returns the number of records within the specified range, and
+	// the total size of their values. The records are counted by each shard, and
+	// the counts are aggregated by the client.
+	// Note: Oxia uses a custom sorting order that treats `/` characters in special way.
+	// Refer to this documentation for the specifics:
+	// https://github.com/streamnative/oxia/blob/main/docs/oxia-key-sorting.md
+	CountRange(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) (count int64, totalValueSize int64, err error)
 
 	// GetNotifications creates a new subscription to receive the notifications
-	// from Oxia for any change that is applied to the database
-	GetNotifications() (Notifications, error)
+	// from Oxia for any change that is applied to the database.
+	// The [FilterKeyPrefix] and [FilterKeyRange] options restrict the
+	// subscription to a subset of the keys. With [ResumeFrom], the subscription
+	// restarts from the offsets saved by a previous one.
+	GetNotifications(options ...NotificationsOption) (Notifications, error)
+
+	// SessionDone returns a channel that is closed once the session used for
+	// the ephemeral records of the partition key is lost, creating the session
+	// if needed.
+	//
+	// A session is lost when the server reports that it has expired, when the
+	// keep-alives have been failing for the whole session timeout, or when the
+	// client is closed. From then on, the ephemeral records written through the
+	// session must be considered deleted, even if the notifications of their
+	// deletion could not be received. A new session is created for the next
+	// ephemeral records.
+	SessionDone(ctx context.Context, partitionKey string) (<-chan struct{}, error)
+
+	// Txn starts a new transaction.
+	//
+	// The operations added to the transaction are applied atomically when it
+	// gets committed: either all of them are applied or none of them is.
+	// All the records in a transaction must be stored in the same shard, which can
+	// be ensured by passing the same [PartitionKey] to all the operations.
+	Txn() SyncTxn
+}
+
+// AsyncTxn accumulates the operations of a transaction created with [AsyncClient.Txn]
+//
+// The put operations are applied before the delete operations, each of them in
+// the order in which they were added.
+type AsyncTxn interface {
+	// Put adds a put operation to the transaction.
+	// It supports the same options as [AsyncClient.Put]
+	Put(key string, value []byte, options ...PutOption) AsyncTxn
+
+	// Delete adds a delete operation to the transaction.
+	// It supports the same options as [AsyncClient.Delete]
+	Delete(key string, options ...DeleteOption) AsyncTxn
+
+	// Commit applies all the operations of the transaction atomically.
+	//
+	// If any of the conditions is not satisfied, none of the operations is applied
+	// and the error of the first failed operation is returned.
+	// Returns [ErrorTransactionSpansShards] if the records are stored in
+	// different shards.
+	Commit() <-chan TxnResult
+}
+
+// SyncTxn accumulates the operations of a transaction created with [SyncClient.Txn]
+//
+// The put operations are applied before the delete operations, each of them in
+// the order in which they were added.
+type SyncTxn interface {
+	// Put adds a put operation to the transaction.
+	// It supports the same options as [SyncClient.Put]
+	Put(key string, value []byte, options ...PutOption) SyncTxn
+
+	// Delete adds a delete operation to the transaction.
+	// It supports the same options as [SyncClient.Delete]
+	Delete(key string, options ...DeleteOption) SyncTxn
+
+	// Commit applies all the operations of the transaction atomically.
+	//
+	// Returns the versions of the records written by the put operations, in
+	// the order in which they were added to the transaction.
+	// If any of the conditions is not satisfied, none of the operations is applied
+	// and the error of the first failed operation is returned.
+	// Returns [ErrorTransactionSpansShards] if the records are stored in
+	// different shards.
+	Commit(ctx context.Context) ([]Version, error)
 }
 
 // Version includes some information regarding the state of a record
 type Version struct {
+	// Key is the key of the record. When the record was written with
+	// [SequentialKeysDeltas], it is the key generated by the server.
+	Key string
+
 	// VersionId represents an identifier that can be used to refer to a particular version
 	// of a record.
 	// Applications shouldn't make assumptions on the actual values of the VersionId. VersionIds
@@ -184,6 +436,19 @@ type Version struct {
 	ClientIdentity string
 }
 
+// PreviousRecord is the state of a record before it was replaced by a put, or
+// removed by a delete. See [ReturnPrevious]
+type PreviousRecord struct {
+	// Exists is false if there was no record
+	Exists bool
+
+	// Value is the previous value of the record
+	Value []byte
+
+	// Version is the previous version of the record
+	Version Version
+}
+
 // PutResult structure is wrapping the version information for the result
 // of a `Put` operation and an eventual error in the [AsyncClient]
 type PutResult struct {
@@ -194,6 +459,32 @@ type PutResult struct {
 	Err error
 }
 
+// IncrementResult structure is wrapping the resulting value and version of an
+// `Increment` operation and an eventual error in the [AsyncClient]
+type IncrementResult struct {
+	// Value is the value of the record after the increment
+	Value int64
+
+	// The Version information
+	Version Version
+
+	// The error if the `Increment` operation failed
+	Err error
+}
+
+// AppendResult structure is wrapping the resulting value and version of an
+// `Append` operation and an eventual error in the [AsyncClient]
+type AppendResult struct {
+	// Value is the value of the record after the append
+	Value []byte
+
+	// The Version information
+	Version Version
+
+	// The error if the `Append` operation failed
+	Err error
+}
+
 // GetResult structure is wrapping a Value, its version information and
 // an eventual error as results for a `Get` operation in the [AsyncClient]
 type GetResult struct {
@@ -207,11 +498,62 @@ type GetResult struct {
 	Err error
 }
 
+// ExistsResult structure is wrapping the outcome of an `Exists` operation
+// and an eventual error in the [AsyncClient]
+type ExistsResult struct {
+	// Exists is true if the record exists
+	Exists bool
+
+	// The version information, if the record exists
+	Version Version
+
+	// The error if the `Exists` operation failed
+	Err error
+}
+
+// CountRangeResult structure is wrapping the number of records in a range, the
+// total size of their values and an eventual error in the [AsyncClient]
+type CountRangeResult struct {
+	// Count is the number of records within the range
+	Count int64
+
+	// TotalValueSize is the total size of the values of the records, in bytes
+	TotalValueSize int64
+
+	// The error if the `CountRange` operation failed
+	Err error
+}
+
+// SessionDoneResult structure is wrapping the channel that is closed once a
+// session is lost and an eventual error in the [AsyncClient]
+type SessionDoneResult struct {
+	// Done is closed once the session is lost
+	Done <-chan struct{}
+
+	// The error if the session could not be created
+	Err error
+}
+
+// TxnResult structure is wrapping the versions of the records written by
+// a transaction and an eventual error in the [AsyncClient]
+type TxnResult struct {
+	// The versions of the records written by the put operations, in the order
+	// in which they were added to the transaction
+	Versions []Version
+
+	// The error if the transaction failed
+	Err error
+}
+
 // ListResult structure is wrapping a list of keys, and a potential error as
 // results for a `List` operation in the [AsyncClient]
 type ListResult struct {
 	// The list of keys returned by [List]
 	Keys []string
+	// The opaque cursor to pass to [ContinueFrom] to retrieve the next page.
+	// It is only set in the last result of a [Limit]ed listing, when more keys
+	// are available
+	Continuation string
 	// The eventual error in the [List] operation
 	Err error
 }
@@ -223,6 +565,12 @@ type Notifications interface {
 
 	// Ch exposes the channel where all the notification events are published
 	Ch() <-chan *Notification
+
+	// Err returns the error that interrupted the notifications, once the channel
+	// is closed. It is ErrorNotificationsTrimmed if the notifications were not
+	// consumed before the servers discarded them, and nil if the subscription
+	// was closed.
+	Err() error
 }
 
 // NotificationType represents the type of the notification event
@@ -260,4 +608,28 @@ type Notification struct {
 
 	// The current VersionId of the record, or -1 for a KeyDeleted event
 	VersionId int64
+
+	// The new value of the record, when the subscription was created with
+	// [IncludeValues]. It is nil for a KeyDeleted event, when the servers don't
+	// store the values in the notifications, or when the value exceeded their
+	// size limits
+	Value []byte
+
+	// The VersionId of the record before the change, when the subscription was
+	// created with [IncludePreviousVersion]. It is -1 for a KeyCreated event
+	PreviousVersionId int64
+
+	// The shard of the record
+	Shard int64
+
+	// The offset of the change in the shard. All the changes applied in the same
+	// write batch share the same offset, and a subscription resumed with
+	// [ResumeFrom] starts after all of them. The offset of a shard must
+	// therefore only be saved once the notification with LastInBatch has been
+	// processed, or the rest of the batch would be skipped
+	Offset int64
+
+	// Whether this is the last notification of the write batch, for the
+	// subscription
+	LastInBatch bool
 }
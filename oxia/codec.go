@@ -0,0 +1,82 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"encoding/json"
+	pb "google.golang.org/protobuf/proto"
+)
+
+// Codec converts the values of a [TypedClient] to and from the bytes that are
+// stored in Oxia.
+type Codec[T any] interface {
+	// Encode serializes a value
+	Encode(value T) ([]byte, error)
+
+	// Decode deserializes a value
+	Decode(data []byte) (T, error)
+}
+
+// NewCodec creates a codec from a pair of serialization functions, such as the
+// ones passed to [NewCache].
+func NewCodec[T any](serializeFunc SerializeFunc, deserializeFunc DeserializeFunc) Codec[T] {
+	return &funcCodec[T]{
+		serializeFunc:   serializeFunc,
+		deserializeFunc: deserializeFunc,
+	}
+}
+
+// JSONCodec creates a codec that stores the values as JSON documents.
+func JSONCodec[T any]() Codec[T] {
+	return NewCodec[T](json.Marshal, json.Unmarshal)
+}
+
+// ProtoCodec creates a codec that stores the values in the protobuf binary
+// format. The type parameter is the pointer type of the message, eg:
+// `ProtoCodec[*mypb.MyMessage]()`.
+func ProtoCodec[T pb.Message]() Codec[T] {
+	return &protoCodec[T]{}
+}
+
+type funcCodec[T any] struct {
+	serializeFunc   SerializeFunc
+	deserializeFunc DeserializeFunc
+}
+
+func (c *funcCodec[T]) Encode(value T) ([]byte, error) {
+	return c.serializeFunc(value)
+}
+
+func (c *funcCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	err := c.deserializeFunc(data, &value)
+	return value, err
+}
+
+type protoCodec[T pb.Message] struct{}
+
+func (c *protoCodec[T]) Encode(value T) ([]byte, error) {
+	return pb.Marshal(value)
+}
+
+func (c *protoCodec[T]) Decode(data []byte) (T, error) {
+	// The reflection of a nil message is still able to create a new instance
+	var zero T
+	value := zero.ProtoReflect().New().Interface().(T)
+	if err := pb.Unmarshal(data, value); err != nil {
+		return zero, err
+	}
+	return value, nil
+}
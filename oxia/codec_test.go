@@ -0,0 +1,57 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"github.com/stretchr/testify/assert"
+	pb "google.golang.org/protobuf/proto"
+	"oxia/proto"
+	"testing"
+)
+
+func TestJSONCodec(t *testing.T) {
+	codec := JSONCodec[testStruct]()
+
+	data, err := codec.Encode(testStruct{"hello", 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":"hello","b":1}`, string(data))
+
+	value, err := codec.Decode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, testStruct{"hello", 1}, value)
+
+	_, err = codec.Decode([]byte("not-json"))
+	assert.Error(t, err)
+}
+
+func TestProtoCodec(t *testing.T) {
+	codec := ProtoCodec[*proto.Version]()
+
+	version := &proto.Version{VersionId: 5, ModificationsCount: 2}
+	data, err := codec.Encode(version)
+	assert.NoError(t, err)
+
+	value, err := codec.Decode(data)
+	assert.NoError(t, err)
+	assert.True(t, pb.Equal(version, value))
+
+	// An empty value is a valid message with the default fields
+	value, err = codec.Decode(nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, value.VersionId)
+
+	_, err = codec.Decode([]byte{0xff})
+	assert.Error(t, err)
+}
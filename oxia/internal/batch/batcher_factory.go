@@ -62,6 +62,19 @@ func (b *BatcherFactory) NewReadBatcher(shardId *int64) batch.Batcher {
 	}.newBatch)
 }
 
+// NewTransaction creates a write batch whose operations are applied atomically
+// by the server. The batch is not attached to any batcher and it is up to the
+// caller to complete it.
+func (b *BatcherFactory) NewTransaction(shardId *int64, maxWriteBatchSize int) batch.Batch {
+	return writeBatchFactory{
+		execute:        b.Executor.ExecuteWrite,
+		metrics:        b.Metrics,
+		requestTimeout: b.RequestTimeout,
+		maxByteSize:    maxWriteBatchSize,
+		transaction:    true,
+	}.newBatch(shardId)
+}
+
 func (b *BatcherFactory) newBatcher(shardId *int64, batchFactory func(shardId *int64) batch.Batch) batch.Batcher {
 	return b.NewBatcher(func() batch.Batch {
 		return batchFactory(shardId)
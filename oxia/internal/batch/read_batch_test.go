@@ -120,8 +120,9 @@ func TestReadBatchComplete(t *testing.T) {
 		}
 
 		batch.Add(model.GetCall{
-			Key:      "/a",
-			Callback: getCallback,
+			Key:          "/a",
+			IncludeValue: true,
+			Callback:     getCallback,
 		})
 		assert.Equal(t, 1, batch.Size())
 
@@ -132,6 +133,53 @@ func TestReadBatchComplete(t *testing.T) {
 	}
 }
 
+func TestReadBatchMixedIncludeValue(t *testing.T) {
+	execute := func(ctx context.Context, request *proto.ReadRequest) (proto.OxiaClient_ReadClient, error) {
+		assert.Equal(t, &proto.ReadRequest{
+			ShardId: &shardId,
+			Gets: []*proto.GetRequest{
+				{Key: "/a", IncludeValue: true},
+				{Key: "/b", IncludeValue: false},
+			},
+		}, request)
+		return readClient([]*proto.ReadResponse{{
+			Gets: []*proto.GetResponse{
+				{Status: proto.Status_OK, Value: []byte{0}},
+				{Status: proto.Status_OK},
+			},
+		}}), nil
+	}
+
+	factory := &readBatchFactory{
+		execute: execute,
+		metrics: metrics.NewMetrics(noop.NewMeterProvider()),
+	}
+	batch := factory.newBatch(&shardId)
+
+	responses := map[string]*proto.GetResponse{}
+	batch.Add(model.GetCall{
+		Key:          "/a",
+		IncludeValue: true,
+		Callback: func(response *proto.GetResponse, err error) {
+			assert.NoError(t, err)
+			responses["/a"] = response
+		},
+	})
+	batch.Add(model.GetCall{
+		Key: "/b",
+		Callback: func(response *proto.GetResponse, err error) {
+			assert.NoError(t, err)
+			responses["/b"] = response
+		},
+	})
+	assert.Equal(t, 2, batch.Size())
+
+	batch.Complete()
+
+	assert.Equal(t, []byte{0}, responses["/a"].Value)
+	assert.Nil(t, responses["/b"].Value)
+}
+
 type readResult struct {
 	response *proto.ReadResponse
 	err      error
@@ -34,6 +34,7 @@ type writeBatchFactory struct {
 	metrics        *metrics.Metrics
 	requestTimeout time.Duration
 	maxByteSize    int
+	transaction    bool
 }
 
 func (b writeBatchFactory) newBatch(shardId *int64) batch.Batch {
@@ -48,6 +49,7 @@ func (b writeBatchFactory) newBatch(shardId *int64) batch.Batch {
 		callback:       b.metrics.WriteCallback(),
 		maxByteSize:    b.maxByteSize,
 		byteSize:       0,
+		transaction:    b.transaction,
 	}
 }
 
@@ -64,6 +66,7 @@ type writeBatch struct {
 	callback       func(time.Time, *proto.WriteRequest, *proto.WriteResponse, error)
 	maxByteSize    int
 	byteSize       int
+	transaction    bool
 }
 
 func (b *writeBatch) CanAdd(call any) bool {
@@ -158,6 +161,7 @@ func (b *writeBatch) toProto() *proto.WriteRequest {
 		Puts:         model.Convert[model.PutCall, *proto.PutRequest](b.puts, model.PutCall.ToProto),
 		Deletes:      model.Convert[model.DeleteCall, *proto.DeleteRequest](b.deletes, model.DeleteCall.ToProto),
 		DeleteRanges: model.Convert[model.DeleteRangeCall, *proto.DeleteRangeRequest](b.deleteRanges, model.DeleteRangeCall.ToProto),
+		Transaction:  b.transaction,
 	}
 }
 
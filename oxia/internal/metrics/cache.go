@@ -0,0 +1,49 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+)
+
+// CacheMetrics counts the outcome of the lookups on the client caches
+type CacheMetrics struct {
+	hits      instrument.Int64Counter
+	misses    instrument.Int64Counter
+	evictions instrument.Int64Counter
+}
+
+func NewCacheMetrics(provider metric.MeterProvider) *CacheMetrics {
+	meter := provider.Meter("oxia_client")
+	return &CacheMetrics{
+		hits:      newCounter(meter, "oxia_client_cache_hits", ""),
+		misses:    newCounter(meter, "oxia_client_cache_misses", ""),
+		evictions: newCounter(meter, "oxia_client_cache_evictions", ""),
+	}
+}
+
+func (m *CacheMetrics) Hit() {
+	m.hits.Add(context.TODO(), 1)
+}
+
+func (m *CacheMetrics) Miss() {
+	m.misses.Add(context.TODO(), 1)
+}
+
+func (m *CacheMetrics) Evicted() {
+	m.evictions.Add(context.TODO(), 1)
+}
@@ -24,12 +24,17 @@ type PutCall struct {
 	ExpectedVersionId *int64
 	SessionId         *int64
 	ClientIdentity    *string
+	SequenceKeyDeltas []uint64
+	TtlMs             *uint64
+	Operation         proto.PutOperation
+	ReturnPrevious    bool
 	Callback          func(*proto.PutResponse, error)
 }
 
 type DeleteCall struct {
 	Key               string
 	ExpectedVersionId *int64
+	ReturnPrevious    bool
 	Callback          func(*proto.DeleteResponse, error)
 }
 
@@ -40,8 +45,10 @@ type DeleteRangeCall struct {
 }
 
 type GetCall struct {
-	Key      string
-	Callback func(*proto.GetResponse, error)
+	Key            string
+	ComparisonType proto.KeyComparisonType
+	IncludeValue   bool
+	Callback       func(*proto.GetResponse, error)
 }
 
 func (r PutCall) ToProto() *proto.PutRequest {
@@ -51,6 +58,10 @@ func (r PutCall) ToProto() *proto.PutRequest {
 		ExpectedVersionId: r.ExpectedVersionId,
 		SessionId:         r.SessionId,
 		ClientIdentity:    r.ClientIdentity,
+		SequenceKeyDelta:  r.SequenceKeyDeltas,
+		TtlMs:             r.TtlMs,
+		Operation:         r.Operation,
+		ReturnPrevious:    r.ReturnPrevious,
 	}
 }
 
@@ -58,6 +69,7 @@ func (r DeleteCall) ToProto() *proto.DeleteRequest {
 	return &proto.DeleteRequest{
 		Key:               r.Key,
 		ExpectedVersionId: r.ExpectedVersionId,
+		ReturnPrevious:    r.ReturnPrevious,
 	}
 }
 
@@ -70,8 +82,9 @@ func (r DeleteRangeCall) ToProto() *proto.DeleteRangeRequest {
 
 func (r GetCall) ToProto() *proto.GetRequest {
 	return &proto.GetRequest{
-		Key:          r.Key,
-		IncludeValue: true,
+		Key:            r.Key,
+		IncludeValue:   r.IncludeValue,
+		ComparisonType: r.ComparisonType,
 	}
 }
 
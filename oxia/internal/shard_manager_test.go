@@ -36,7 +36,7 @@ func TestWithStandalone(t *testing.T) {
 	server, err := server.NewStandalone(server.NewTestConfig())
 	assert.NoError(t, err)
 
-	clientPool := common.NewClientPool()
+	clientPool := common.NewClientPool(nil, nil)
 	serviceAddress := fmt.Sprintf("localhost:%d", server.RpcPort())
 	shardManager, err := NewShardManager(&testShardStrategy{}, clientPool, serviceAddress, common.DefaultNamespace, 30*time.Second)
 	assert.NoError(t, err)
@@ -18,16 +18,21 @@ import (
 	"context"
 	"fmt"
 	"github.com/cenkalti/backoff/v4"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/status"
 	"io"
 	"oxia/common"
 	"oxia/oxia/internal"
 	"oxia/proto"
+	"sync"
 	"time"
 )
 
 type notifications struct {
+	sync.Mutex
+	options      notificationsOptions
 	multiplexCh  chan *Notification
 	closeCh      chan any
 	shardManager internal.ShardManager
@@ -39,10 +44,15 @@ type notifications struct {
 
 	ctxMultiplexChanClosed    context.Context
 	cancelMultiplexChanClosed context.CancelFunc
+
+	// The error that interrupted the notifications
+	err error
 }
 
-func newNotifications(options clientOptions, ctx context.Context, clientPool common.ClientPool, shardManager internal.ShardManager) (*notifications, error) {
+func newNotifications(options clientOptions, notificationsOptions notificationsOptions, ctx context.Context,
+	clientPool common.ClientPool, shardManager internal.ShardManager) (*notifications, error) {
 	nm := &notifications{
+		options:      notificationsOptions,
 		multiplexCh:  make(chan *Notification, 100),
 		closeCh:      make(chan any),
 		shardManager: shardManager,
@@ -88,6 +98,28 @@ func (nm *notifications) Ch() <-chan *Notification {
 	return nm.multiplexCh
 }
 
+func (nm *notifications) Err() error {
+	select {
+	case <-nm.ctxMultiplexChanClosed.Done():
+		nm.Lock()
+		defer nm.Unlock()
+		return nm.err
+	default:
+		return nil
+	}
+}
+
+// fail interrupts all the notifications because of a non-retriable error
+func (nm *notifications) fail(err error) {
+	nm.Lock()
+	defer nm.Unlock()
+
+	if nm.err == nil {
+		nm.err = err
+	}
+	nm.cancel()
+}
+
 func (nm *notifications) Close() error {
 	// Interrupt the go-routines receiving notifications on all the shards
 	nm.cancel()
@@ -98,7 +130,7 @@ func (nm *notifications) Close() error {
 
 	// Ensure the channel is empty, so that the user will not see any notifications
 	// after the close
-	for n := <-nm.multiplexCh; n != nil; {
+	for range nm.multiplexCh {
 	}
 
 	return nil
@@ -113,16 +145,24 @@ type shardNotificationsManager struct {
 	nm                 *notifications
 	backoff            backoff.BackOff
 	lastOffsetReceived int64
+	resume             bool
 	initialized        bool
 	log                zerolog.Logger
 }
 
 func newShardNotificationsManager(shard int64, nm *notifications) *shardNotificationsManager {
+	lastOffsetReceived := int64(-1)
+	resumeOffset, resume := nm.options.resumeFrom[shard]
+	if resume {
+		lastOffsetReceived = resumeOffset
+	}
+
 	snm := &shardNotificationsManager{
 		shard:              shard,
 		ctx:                nm.ctx,
 		nm:                 nm,
-		lastOffsetReceived: -1,
+		lastOffsetReceived: lastOffsetReceived,
+		resume:             resume,
 		backoff:            common.NewBackOffWithInitialInterval(nm.ctx, 1*time.Second),
 		log: log.Logger.With().
 			Str("component", "oxia-notifications-manager").
@@ -139,7 +179,7 @@ func newShardNotificationsManager(shard int64, nm *notifications) *shardNotifica
 }
 
 func (snm *shardNotificationsManager) getNotificationsWithRetries() {
-	_ = backoff.RetryNotify(snm.getNotifications,
+	err := backoff.RetryNotify(snm.getNotifications,
 		snm.backoff, func(err error, duration time.Duration) {
 			if err != context.Canceled {
 				snm.log.Error().Err(err).
@@ -153,6 +193,20 @@ func (snm *shardNotificationsManager) getNotificationsWithRetries() {
 				snm.nm.cancel()
 			}
 		})
+
+	if errors.Is(err, ErrorNotificationsTrimmed) {
+		snm.log.Warn().
+			Int64("last-offset-received", snm.lastOffsetReceived).
+			Msg("The notifications were already trimmed")
+		if !snm.initialized {
+			snm.initialized = true
+			snm.nm.initWaitGroup.Fail(err)
+		}
+		snm.nm.fail(err)
+	}
+
+	// The retries only stop once the subscription is closed or has failed
+	snm.nm.closeCh <- nil
 }
 
 func (snm *shardNotificationsManager) getNotifications() error {
@@ -164,17 +218,22 @@ func (snm *shardNotificationsManager) getNotifications() error {
 	}
 
 	var startOffsetExclusive *int64
-	if snm.lastOffsetReceived >= 0 {
+	if snm.lastOffsetReceived >= 0 || snm.resume {
 		startOffsetExclusive = &snm.lastOffsetReceived
 	}
 
 	notifications, err := rpc.GetNotifications(snm.ctx, &proto.NotificationsRequest{
 		ShardId:              snm.shard,
 		StartOffsetExclusive: startOffsetExclusive,
+		KeyPrefixes:          snm.nm.options.keyPrefixes,
+		KeyMinInclusive:      snm.nm.options.keyMinInclusive,
+		KeyMaxExclusive:      snm.nm.options.keyMaxExclusive,
+
+		IncludeValues:          snm.nm.options.includeValues,
+		IncludePreviousVersion: snm.nm.options.includePreviousVersion,
 	})
 	if err != nil {
 		if snm.ctx.Err() != nil {
-			snm.nm.closeCh <- nil
 			return snm.ctx.Err()
 		}
 		return err
@@ -182,16 +241,23 @@ func (snm *shardNotificationsManager) getNotifications() error {
 
 	snm.backoff.Reset()
 
+	if snm.resume && !snm.initialized {
+		// There is no initial notification when resuming from an offset
+		snm.initialized = true
+		snm.nm.initWaitGroup.Done()
+	}
+
 	for {
 		nb, err := notifications.Recv()
 		if err != nil {
 			if snm.ctx.Err() != nil {
-				snm.nm.closeCh <- nil
+				return snm.ctx.Err()
+			} else if status.Code(err) == common.CodeNotificationsTrimmed {
+				return backoff.Permanent(ErrorNotificationsTrimmed)
 			}
 			return err
 		} else if nb == nil {
 			if snm.ctx.Err() != nil {
-				snm.nm.closeCh <- nil
 				return snm.ctx.Err()
 			}
 			return io.EOF
@@ -214,17 +280,20 @@ func (snm *shardNotificationsManager) getNotifications() error {
 			continue
 		}
 
+		remaining := len(nb.Notifications)
 		for key, n := range nb.Notifications {
+			remaining--
 			select {
-			case snm.nm.multiplexCh <- convertNotification(key, n):
+			case snm.nm.multiplexCh <- convertNotification(nb, key, n, remaining == 0):
 
 			// Unblock from channel write when we're closing down
 			case <-snm.ctx.Done():
-				snm.nm.closeCh <- nil
 				return snm.ctx.Err()
 			}
 		}
 
+		// The batches without notifications for this subscription also move
+		// the cursor forward
 		snm.lastOffsetReceived = nb.Offset
 	}
 }
@@ -242,14 +311,23 @@ func convertNotificationType(t proto.NotificationType) NotificationType {
 	}
 }
 
-func convertNotification(key string, n *proto.Notification) *Notification {
+func convertNotification(nb *proto.NotificationBatch, key string, n *proto.Notification, lastInBatch bool) *Notification {
 	versionId := int64(-1)
 	if n.VersionId != nil {
 		versionId = *n.VersionId
 	}
+	previousVersionId := int64(-1)
+	if n.PreviousVersionId != nil {
+		previousVersionId = *n.PreviousVersionId
+	}
 	return &Notification{
-		Type:      convertNotificationType(n.Type),
-		Key:       key,
-		VersionId: versionId,
+		Type:              convertNotificationType(n.Type),
+		Key:               key,
+		VersionId:         versionId,
+		Value:             n.Value,
+		PreviousVersionId: previousVersionId,
+		Shard:             nb.ShardId,
+		Offset:            nb.Offset,
+		LastInBatch:       lastInBatch,
 	}
 }
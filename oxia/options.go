@@ -15,8 +15,12 @@
 package oxia
 
 import (
+	"crypto/tls"
 	"go.opentelemetry.io/otel/metric/noop"
+	"math"
 	"oxia/common"
+	"oxia/proto"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,6 +37,9 @@ const (
 	DefaultRequestTimeout      = 30 * time.Second
 	DefaultSessionTimeout      = 15 * time.Second
 	DefaultNamespace           = common.DefaultNamespace
+	DefaultCacheMaxEntries     = 100_000
+	DefaultCacheMaxBytes       = 64 * 1024 * 1024
+	DefaultCacheTTL            = 5 * time.Minute
 )
 
 var (
@@ -43,6 +50,12 @@ var (
 	ErrorInvalidOptionSessionTimeout      = errors.New("SessionTimeout must be greater than zero")
 	ErrorInvalidOptionIdentity            = errors.New("Identity must be non-empty")
 	ErrorInvalidOptionNamespace           = errors.New("Namespace cannot be empty")
+	ErrorInvalidOptionTTL                 = errors.New("TTL must be greater than zero")
+	ErrorInvalidOptionLimit               = errors.New("Limit must be greater than zero")
+	ErrorInvalidOptionCacheMaxEntries     = errors.New("Cache MaxEntries must be greater than zero")
+	ErrorInvalidOptionCacheMaxBytes       = errors.New("Cache MaxBytes must be greater than zero")
+	ErrorInvalidOptionCacheTTL            = errors.New("Cache TTL must be greater than zero")
+	ErrorInvalidOptionCacheNegativeTTL    = errors.New("Cache NegativeTTL must be greater than or equal to zero")
 )
 
 // clientOptions contains options for the Oxia client.
@@ -56,6 +69,8 @@ type clientOptions struct {
 	meterProvider       metric.MeterProvider
 	sessionTimeout      time.Duration
 	identity            string
+	tlsConf             *tls.Config
+	authentication      Authentication
 }
 
 func defaultIdentity() string {
@@ -160,6 +175,27 @@ func WithMeterProvider(meterProvider metric.MeterProvider) ClientOption {
 	})
 }
 
+// WithTLS makes the client connect to the servers over TLS, with the given
+// configuration. The configuration can be created from the certificate files
+// with the `TLSOptions` of the `oxia/common/security` package.
+// If not set, the client connects in plaintext.
+func WithTLS(tlsConf *tls.Config) ClientOption {
+	return clientOptionFunc(func(options clientOptions) (clientOptions, error) {
+		options.tlsConf = tlsConf
+		return options, nil
+	})
+}
+
+// WithAuthentication attaches the credentials to all the calls of the client,
+// including the streams of the shard assignments, the notifications and the
+// sessions. If not set, the client is not authenticated.
+func WithAuthentication(authentication Authentication) ClientOption {
+	return clientOptionFunc(func(options clientOptions) (clientOptions, error) {
+		options.authentication = authentication
+		return options, nil
+	})
+}
+
 // WithGlobalMeterProvider instructs the Oxia client to use the global OpenTelemetry MeterProvider.
 func WithGlobalMeterProvider() ClientOption {
 	return WithMeterProvider(global.MeterProvider())
@@ -189,8 +225,12 @@ func WithIdentity(identity string) ClientOption {
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 type putOptions struct {
-	expectedVersion *int64
-	ephemeral       bool
+	expectedVersion   *int64
+	ephemeral         bool
+	partitionKey      *string
+	sequenceKeyDeltas []uint64
+	ttl               *time.Duration
+	previous          *PreviousRecord
 }
 
 // PutOption represents an option for the [SyncClient.Put] operation
@@ -206,14 +246,81 @@ func newPutOptions(opts []PutOption) putOptions {
 	return putOpts
 }
 
+func (o putOptions) validate() error {
+	if o.ttl != nil && o.ttl.Milliseconds() <= 0 {
+		return ErrorInvalidOptionTTL
+	}
+	if len(o.sequenceKeyDeltas) == 0 {
+		return nil
+	}
+	if o.partitionKey == nil || o.expectedVersion != nil || o.sequenceKeyDeltas[0] == 0 {
+		return ErrorInvalidSequentialKeys
+	}
+	return nil
+}
+
+func (o putOptions) ttlMs() *uint64 {
+	if o.ttl == nil {
+		return nil
+	}
+	ttlMs := uint64(o.ttl.Milliseconds())
+	return &ttlMs
+}
+
 // ExpectedRecordNotExists Marks that the put operation should only be successful
 // if the record does not exist yet.
 func ExpectedRecordNotExists() PutOption {
 	return &expectedVersionId{VersionIdNotExists}
 }
 
+type sequentialKeysDeltas struct {
+	deltas []uint64
+}
+
+func (s *sequentialKeysDeltas) applyPut(opts putOptions) putOptions {
+	opts.sequenceKeyDeltas = s.deltas
+	return opts
+}
+
+// SequentialKeysDeltas asks the server to generate a unique key for the record, by
+// appending to the key a zero-padded suffix for each of the deltas, e.g. for
+// `/queue/item` and deltas `1`, the server generates `/queue/item-00000000000000000001`,
+// `/queue/item-00000000000000000002` and so on.
+//
+// Each suffix is taken from a shard-local sequence, associated to the key, which is
+// increased by the corresponding delta. The keys generated for the same key are then
+// monotonically increasing. The generated key is returned in [Version.Key].
+//
+// The first delta must be greater than zero, a [PartitionKey] is required, so that all
+// the generated keys are stored in the same shard, and the option cannot be combined
+// with [ExpectedVersionId].
+func SequentialKeysDeltas(delta ...uint64) PutOption {
+	return &sequentialKeysDeltas{delta}
+}
+
+type ttl struct {
+	ttl time.Duration
+}
+
+func (t *ttl) applyPut(opts putOptions) putOptions {
+	opts.ttl = &t.ttl
+	return opts
+}
+
+// WithTTL sets the time to live of the record, which gets automatically deleted by
+// the server once the ttl has elapsed, even if the client is still alive.
+// The ttl is measured from the moment the put is applied by the server and it is
+// replaced by any subsequent put on the same record.
+// Expired records are deleted in the background, so they might still be visible
+// for a short time after their expiration.
+func WithTTL(ttlDuration time.Duration) PutOption {
+	return &ttl{ttlDuration}
+}
+
 type deleteOptions struct {
 	expectedVersion *int64
+	partitionKey    *string
+	previous        *PreviousRecord
 }
 
 // DeleteOption represents an option for the [SyncClient.Delete] operation
@@ -250,6 +357,27 @@ func (e *expectedVersionId) applyDelete(opts deleteOptions) deleteOptions {
 	return opts
 }
 
+// ReturnPrevious asks the server to return the record that was replaced by a
+// put, or removed by a delete. Once the operation has completed successfully,
+// the previous record is stored in the passed struct.
+func ReturnPrevious(previous *PreviousRecord) DeleteOption {
+	return &returnPrevious{previous}
+}
+
+type returnPrevious struct {
+	previous *PreviousRecord
+}
+
+func (r *returnPrevious) applyPut(opts putOptions) putOptions {
+	opts.previous = r.previous
+	return opts
+}
+
+func (r *returnPrevious) applyDelete(opts deleteOptions) deleteOptions {
+	opts.previous = r.previous
+	return opts
+}
+
 type ephemeral struct{}
 
 var ephemeralFlag = &ephemeral{}
@@ -270,3 +398,400 @@ func (e *ephemeral) applyPut(opts putOptions) putOptions {
 func Ephemeral() PutOption {
 	return ephemeralFlag
 }
+
+type getOptions struct {
+	partitionKey   *string
+	comparisonType proto.KeyComparisonType
+}
+
+// GetOption represents an option for the [SyncClient.Get] operation
+type GetOption interface {
+	applyGet(opts getOptions) getOptions
+}
+
+func newGetOptions(opts []GetOption) getOptions {
+	getOpts := getOptions{}
+	for _, opt := range opts {
+		getOpts = opt.applyGet(getOpts)
+	}
+	return getOpts
+}
+
+type comparisonType struct {
+	comparisonType proto.KeyComparisonType
+}
+
+func (c *comparisonType) applyGet(opts getOptions) getOptions {
+	opts.comparisonType = c.comparisonType
+	return opts
+}
+
+// ComparisonEqual sets the Get() operation to compare the stored key for equality.
+// This is the default.
+func ComparisonEqual() GetOption {
+	return &comparisonType{proto.KeyComparisonType_EQUAL}
+}
+
+// ComparisonFloor sets the Get() operation to return the record with the highest
+// key that is lower than or equal to the requested key.
+func ComparisonFloor() GetOption {
+	return &comparisonType{proto.KeyComparisonType_FLOOR}
+}
+
+// ComparisonCeiling sets the Get() operation to return the record with the lowest
+// key that is higher than or equal to the requested key.
+func ComparisonCeiling() GetOption {
+	return &comparisonType{proto.KeyComparisonType_CEILING}
+}
+
+// ComparisonLower sets the Get() operation to return the record with the highest
+// key that is strictly lower than the requested key.
+func ComparisonLower() GetOption {
+	return &comparisonType{proto.KeyComparisonType_LOWER}
+}
+
+// ComparisonHigher sets the Get() operation to return the record with the lowest
+// key that is strictly higher than the requested key.
+func ComparisonHigher() GetOption {
+	return &comparisonType{proto.KeyComparisonType_HIGHER}
+}
+
+type listOptions struct {
+	partitionKey *string
+	limit        *int
+	reverse      bool
+	continuation *string
+}
+
+// ListOption represents an option for the [SyncClient.List] operation
+type ListOption interface {
+	applyList(opts listOptions) listOptions
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	listOpts := listOptions{}
+	for _, opt := range opts {
+		listOpts = opt.applyList(listOpts)
+	}
+	return listOpts
+}
+
+func (o listOptions) validate() error {
+	if o.limit != nil && *o.limit <= 0 {
+		return ErrorInvalidOptionLimit
+	}
+	return nil
+}
+
+// limitProto is the number of keys requested to each shard: one more than the
+// limit, so that the continuation is only set when there are keys after the
+// page, even when they are all on the same shard
+func (o listOptions) limitProto() *uint32 {
+	if o.limit == nil || int64(*o.limit) >= math.MaxUint32 {
+		return nil
+	}
+	limit := uint32(*o.limit) + 1
+	return &limit
+}
+
+// BaseOption represents an option that can be passed to any of the
+// [SyncClient.Put], [SyncClient.Delete], [SyncClient.Get] and [SyncClient.List] operations
+type BaseOption interface {
+	DeleteOption
+	GetOption
+	ListOption
+}
+
+type partitionKey struct {
+	key string
+}
+
+func (p *partitionKey) applyPut(opts putOptions) putOptions {
+	opts.partitionKey = &p.key
+	return opts
+}
+
+func (p *partitionKey) applyDelete(opts deleteOptions) deleteOptions {
+	opts.partitionKey = &p.key
+	return opts
+}
+
+func (p *partitionKey) applyGet(opts getOptions) getOptions {
+	opts.partitionKey = &p.key
+	return opts
+}
+
+func (p *partitionKey) applyList(opts listOptions) listOptions {
+	opts.partitionKey = &p.key
+	return opts
+}
+
+// PartitionKey overrides the key used to decide which shard a record belongs to.
+//
+// By default, the shard is selected by hashing the record key. All the records that
+// are written with the same partition key are stored in the same shard, which
+// makes it possible to include them in the same transaction.
+// The same partition key must then be passed when reading or deleting the records.
+// When passed to [SyncClient.List], only the shard of the partition key is queried.
+// The partition key is only used by the client to select the shard, and is not
+// sent to the servers, which don't check it.
+func PartitionKey(key string) BaseOption {
+	return &partitionKey{key}
+}
+
+type limit struct {
+	limit int
+}
+
+func (l *limit) applyList(opts listOptions) listOptions {
+	opts.limit = &l.limit
+	return opts
+}
+
+// Limit sets the maximum number of keys returned by [SyncClient.List].
+// If more keys are available, the last [ListResult] carries a continuation
+// that can be passed to [ContinueFrom] to retrieve the next page.
+func Limit(n int) ListOption {
+	return &limit{n}
+}
+
+type reverse struct{}
+
+func (reverse) applyList(opts listOptions) listOptions {
+	opts.reverse = true
+	return opts
+}
+
+// Reverse makes [SyncClient.List] return the keys in descending order.
+func Reverse() ListOption {
+	return reverse{}
+}
+
+type continueFrom struct {
+	continuation string
+}
+
+func (c *continueFrom) applyList(opts listOptions) listOptions {
+	opts.continuation = &c.continuation
+	return opts
+}
+
+// ContinueFrom resumes a [SyncClient.List] operation from the continuation
+// returned in the last [ListResult] of the previous page. The range and the
+// other options must be the same as for the previous page.
+func ContinueFrom(continuation string) ListOption {
+	return &continueFrom{continuation}
+}
+
+type notificationsOptions struct {
+	keyPrefixes            []string
+	keyMinInclusive        *string
+	keyMaxExclusive        *string
+	includeValues          bool
+	includePreviousVersion bool
+	resumeFrom             map[int64]int64
+}
+
+// NotificationsOption represents an option for the [SyncClient.GetNotifications] operation
+type NotificationsOption interface {
+	applyNotifications(opts notificationsOptions) notificationsOptions
+}
+
+func newNotificationsOptions(opts []NotificationsOption) notificationsOptions {
+	notificationsOpts := notificationsOptions{}
+	for _, opt := range opts {
+		notificationsOpts = opt.applyNotifications(notificationsOpts)
+	}
+	return notificationsOpts
+}
+
+type filterKeyPrefix struct {
+	prefix string
+}
+
+func (f *filterKeyPrefix) applyNotifications(opts notificationsOptions) notificationsOptions {
+	opts.keyPrefixes = append(opts.keyPrefixes, f.prefix)
+	return opts
+}
+
+// FilterKeyPrefix restricts the notifications to the keys that start with the
+// given prefix. The filter is applied by the servers, so that the notifications
+// for the other keys are not even transferred. When the option is passed multiple
+// times, the keys matching any of the prefixes are included.
+func FilterKeyPrefix(prefix string) NotificationsOption {
+	return &filterKeyPrefix{prefix}
+}
+
+type filterKeyRange struct {
+	minKeyInclusive string
+	maxKeyExclusive string
+}
+
+func (f *filterKeyRange) applyNotifications(opts notificationsOptions) notificationsOptions {
+	opts.keyMinInclusive = &f.minKeyInclusive
+	opts.keyMaxExclusive = &f.maxKeyExclusive
+	return opts
+}
+
+// FilterKeyRange restricts the notifications to the keys within the given range.
+// The filter is applied by the servers and, when combined with [FilterKeyPrefix],
+// the keys must match both filters.
+func FilterKeyRange(minKeyInclusive string, maxKeyExclusive string) NotificationsOption {
+	return &filterKeyRange{minKeyInclusive, maxKeyExclusive}
+}
+
+type includeValues struct{}
+
+func (includeValues) applyNotifications(opts notificationsOptions) notificationsOptions {
+	opts.includeValues = true
+	return opts
+}
+
+// IncludeValues makes the notifications carry the new value of the records, in
+// [Notification.Value], so that there is no need to read them after each event.
+// The values are only included when the servers store them in the notifications,
+// and when they don't exceed the size limits of the notifications.
+func IncludeValues() NotificationsOption {
+	return includeValues{}
+}
+
+type includePreviousVersion struct{}
+
+func (includePreviousVersion) applyNotifications(opts notificationsOptions) notificationsOptions {
+	opts.includePreviousVersion = true
+	return opts
+}
+
+// IncludePreviousVersion makes the notifications carry the version id that the
+// records had before the change, in [Notification.PreviousVersionId].
+func IncludePreviousVersion() NotificationsOption {
+	return includePreviousVersion{}
+}
+
+type resumeFrom struct {
+	offsets map[int64]int64
+}
+
+func (r *resumeFrom) applyNotifications(opts notificationsOptions) notificationsOptions {
+	opts.resumeFrom = r.offsets
+	return opts
+}
+
+// ResumeFrom starts the notifications right after the given offsets, keyed by
+// shard, which are taken from [Notification.Shard] and [Notification.Offset].
+// The shards missing from the map start from the next change. If the servers have
+// already discarded the requested notifications, the channel is closed and
+// [Notifications.Err] returns ErrorNotificationsTrimmed.
+func ResumeFrom(offsets map[int64]int64) NotificationsOption {
+	return &resumeFrom{offsets}
+}
+
+// cacheOptions contains options for a [Cache].
+type cacheOptions struct {
+	maxEntries    int
+	maxBytes      int64
+	ttl           time.Duration
+	negativeTTL   time.Duration
+	preloadPrefix *string
+}
+
+// CacheOption is an interface for applying [Cache] options.
+type CacheOption interface {
+	applyCache(option cacheOptions) (cacheOptions, error)
+}
+
+func newCacheOptions(opts []CacheOption) (cacheOptions, error) {
+	options := cacheOptions{
+		maxEntries:  DefaultCacheMaxEntries,
+		maxBytes:    DefaultCacheMaxBytes,
+		ttl:         DefaultCacheTTL,
+		negativeTTL: DefaultCacheTTL,
+	}
+	var errs error
+	var err error
+	for _, o := range opts {
+		options, err = o.applyCache(options)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return options, errs
+}
+
+type cacheOptionFunc func(cacheOptions) (cacheOptions, error)
+
+func (f cacheOptionFunc) applyCache(c cacheOptions) (cacheOptions, error) {
+	return f(c)
+}
+
+// WithCacheMaxEntries defines how many records the cache can hold. When the
+// limit is exceeded, the least recently used records are evicted.
+func WithCacheMaxEntries(maxEntries int) CacheOption {
+	return cacheOptionFunc(func(options cacheOptions) (cacheOptions, error) {
+		if maxEntries <= 0 {
+			return options, ErrorInvalidOptionCacheMaxEntries
+		}
+		options.maxEntries = maxEntries
+		return options, nil
+	})
+}
+
+// WithCacheMaxBytes defines the maximum total size of the serialized values held
+// by the cache. When the limit is exceeded, the least recently used records are
+// evicted.
+func WithCacheMaxBytes(maxBytes int64) CacheOption {
+	return cacheOptionFunc(func(options cacheOptions) (cacheOptions, error) {
+		if maxBytes <= 0 {
+			return options, ErrorInvalidOptionCacheMaxBytes
+		}
+		options.maxBytes = maxBytes
+		return options, nil
+	})
+}
+
+// WithCacheTTL defines how long a record is kept in the cache after it was read.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return cacheOptionFunc(func(options cacheOptions) (cacheOptions, error) {
+		if ttl <= 0 {
+			return options, ErrorInvalidOptionCacheTTL
+		}
+		options.ttl = ttl
+		return options, nil
+	})
+}
+
+// WithCacheNegativeTTL defines how long the cache remembers that a key does not
+// exist. A value of zero disables the caching of the missing keys, so that they
+// are always looked up on the servers.
+func WithCacheNegativeTTL(negativeTTL time.Duration) CacheOption {
+	return cacheOptionFunc(func(options cacheOptions) (cacheOptions, error) {
+		if negativeTTL < 0 {
+			return options, ErrorInvalidOptionCacheNegativeTTL
+		}
+		options.negativeTTL = negativeTTL
+		return options, nil
+	})
+}
+
+// WithCachePreloadPrefix makes the cache load all the records below the path,
+// at any depth, when it is created, and keep them up to date by following the
+// notifications. The reads of these keys never go to the servers, and the
+// records are neither evicted nor expired.
+// When the servers store the values in the notifications, the records are
+// updated from the notifications without being read, and their [Version] then
+// only carries the Key and the VersionId.
+func WithCachePreloadPrefix(path string) CacheOption {
+	return cacheOptionFunc(func(options cacheOptions) (cacheOptions, error) {
+		path = strings.TrimSuffix(path, "/")
+		options.preloadPrefix = &path
+		return options, nil
+	})
+}
+
+// shardingKey returns the key that determines the shard for a record.
+func shardingKey(key string, partitionKey *string) string {
+	if partitionKey != nil {
+		return *partitionKey
+	}
+	return key
+}
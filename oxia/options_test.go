@@ -15,6 +15,9 @@
 package oxia
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -77,3 +80,64 @@ func TestWithRequestTimeout(t *testing.T) {
 		assert.ErrorIs(t, err, item.expectedErr)
 	}
 }
+
+func TestNewCacheOptions(t *testing.T) {
+	options, err := newCacheOptions(nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, DefaultCacheMaxEntries, options.maxEntries)
+	assert.EqualValues(t, DefaultCacheMaxBytes, options.maxBytes)
+	assert.Equal(t, DefaultCacheTTL, options.ttl)
+	assert.Equal(t, DefaultCacheTTL, options.negativeTTL)
+	assert.Nil(t, options.preloadPrefix)
+
+	options, err = newCacheOptions([]CacheOption{WithCachePreloadPrefix("/a/b/")})
+	assert.NoError(t, err)
+	assert.Equal(t, "/a/b", *options.preloadPrefix)
+}
+
+func TestWithCacheNegativeTTL(t *testing.T) {
+	for _, item := range []struct {
+		negativeTTL         time.Duration
+		expectedNegativeTTL time.Duration
+		expectedErr         error
+	}{
+		{-1, DefaultCacheTTL, ErrorInvalidOptionCacheNegativeTTL},
+		{0, 0, nil},
+		{1, 1, nil},
+	} {
+		options, err := newCacheOptions([]CacheOption{WithCacheNegativeTTL(item.negativeTTL)})
+		assert.Equal(t, item.expectedNegativeTTL, options.negativeTTL)
+		assert.ErrorIs(t, err, item.expectedErr)
+	}
+}
+
+func TestWithTLS(t *testing.T) {
+	options, err := newClientOptions("serviceAddress")
+	assert.NoError(t, err)
+	assert.Nil(t, options.tlsConf)
+
+	tlsConf := &tls.Config{ServerName: "oxia"}
+	options, err = newClientOptions("serviceAddress", WithTLS(tlsConf))
+	assert.NoError(t, err)
+	assert.Same(t, tlsConf, options.tlsConf)
+}
+
+func TestWithAuthentication(t *testing.T) {
+	options, err := newClientOptions("serviceAddress")
+	assert.NoError(t, err)
+	assert.Nil(t, options.authentication)
+
+	options, err = newClientOptions("serviceAddress", WithAuthentication(NewTokenAuthentication("my-token")))
+	assert.NoError(t, err)
+
+	md, err := options.authentication.GetRequestMetadata(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer my-token"}, md)
+
+	failing := NewTokenProviderAuthentication(func() (string, error) {
+		return "", errors.New("token not available")
+	})
+	_, err = failing.GetRequestMetadata(context.Background())
+	assert.Error(t, err)
+}
@@ -14,16 +14,49 @@
 
 package oxia
 
-import "oxia/proto"
+import (
+	"oxia/proto"
+	"strconv"
+)
 
-func toPutResult(r *proto.PutResponse) PutResult {
+func toPutResult(key string, r *proto.PutResponse) PutResult {
 	if err := toError(r.Status); err != nil {
 		return PutResult{
 			Err: err,
 		}
 	}
 	return PutResult{
-		Version: toVersion(r.Version),
+		Version: toPutVersion(key, r),
+	}
+}
+
+func toIncrementResult(key string, r *proto.PutResponse) IncrementResult {
+	if err := toError(r.Status); err != nil {
+		return IncrementResult{
+			Err: err,
+		}
+	}
+	value, err := strconv.ParseInt(string(r.Value), 10, 64)
+	if err != nil {
+		return IncrementResult{
+			Err: ErrorInvalidValue,
+		}
+	}
+	return IncrementResult{
+		Value:   value,
+		Version: toPutVersion(key, r),
+	}
+}
+
+func toAppendResult(key string, r *proto.PutResponse) AppendResult {
+	if err := toError(r.Status); err != nil {
+		return AppendResult{
+			Err: err,
+		}
+	}
+	return AppendResult{
+		Value:   r.Value,
+		Version: toPutVersion(key, r),
 	}
 }
 
@@ -35,20 +68,87 @@ func toDeleteRangeResult(r *proto.DeleteRangeResponse) error {
 	return toError(r.Status)
 }
 
-func toGetResult(r *proto.GetResponse) GetResult {
+func toGetResult(key string, r *proto.GetResponse) GetResult {
 	if err := toError(r.Status); err != nil {
 		return GetResult{
 			Err: err,
 		}
 	}
+	if r.Key != nil {
+		// The matched key is different from the requested one, when
+		// using a comparison type other than equal
+		key = *r.Key
+	}
 	return GetResult{
 		Value:   r.Value,
-		Version: toVersion(r.Version),
+		Version: toVersion(key, r.Version),
+	}
+}
+
+func toCountRangeResult(responses []*proto.CountRangeResponse) CountRangeResult {
+	result := CountRangeResult{}
+	for _, r := range responses {
+		result.Count += r.Count
+		result.TotalValueSize += r.TotalValueSize
+	}
+	return result
+}
+
+func toTxnResult(putKeys []string, puts []*proto.PutResponse, deletes []*proto.DeleteResponse) TxnResult {
+	var err error
+	for _, r := range puts {
+		err = firstTxnError(err, toError(r.Status))
+	}
+	for _, r := range deletes {
+		err = firstTxnError(err, toError(r.Status))
+	}
+	if err != nil {
+		return TxnResult{Err: err}
+	}
+
+	versions := make([]Version, len(puts))
+	for i, r := range puts {
+		versions[i] = toPutVersion(putKeys[i], r)
+	}
+	return TxnResult{Versions: versions}
+}
+
+// firstTxnError gives precedence to the error of the operation that caused
+// the transaction to be aborted
+func firstTxnError(current error, err error) error {
+	if err != nil && (current == nil || current == ErrorTransactionAborted) {
+		return err
+	}
+	return current
+}
+
+// toPutVersion uses the key generated by the server, if there is one
+func toPutVersion(key string, r *proto.PutResponse) Version {
+	if r.Key != nil {
+		key = *r.Key
+	}
+	return toVersion(key, r.Version)
+}
+
+// setPrevious stores the previous record returned by a successful put or delete
+func setPrevious(previous *PreviousRecord, key string, status proto.Status, value []byte, version *proto.Version) {
+	if previous == nil || status != proto.Status_OK {
+		return
+	}
+	if version == nil {
+		*previous = PreviousRecord{}
+		return
+	}
+	*previous = PreviousRecord{
+		Exists:  true,
+		Value:   value,
+		Version: toVersion(key, version),
 	}
 }
 
-func toVersion(version *proto.Version) Version {
+func toVersion(key string, version *proto.Version) Version {
 	v := Version{
+		Key:                key,
 		VersionId:          version.VersionId,
 		ModificationsCount: version.ModificationsCount,
 		CreatedTimestamp:   version.CreatedTimestamp,
@@ -70,6 +170,14 @@ func toError(status proto.Status) error {
 		return ErrorUnexpectedVersionId
 	case proto.Status_KEY_NOT_FOUND:
 		return ErrorKeyNotFound
+	case proto.Status_TRANSACTION_ABORTED:
+		return ErrorTransactionAborted
+	case proto.Status_INVALID_VALUE:
+		return ErrorInvalidValue
+	case proto.Status_PERMISSION_DENIED:
+		return ErrorPermissionDenied
+	case proto.Status_INVALID_KEY:
+		return ErrorInvalidKey
 	default:
 		return ErrorUnknownStatus
 	}
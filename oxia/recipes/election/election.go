@@ -0,0 +1,529 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package election implements a leader election on top of Oxia ephemeral records.
+//
+// Each candidate appends an ephemeral record with a sequential key to the queue
+// of the election, and the candidate with the lowest key is the leader. Since
+// the records are ephemeral, the leadership is lost when the session of the
+// client expires, either because the client was closed or because it could not
+// keep the session alive. The leader gives up as soon as the client reports the
+// loss of the session, even if the service is not reachable anymore.
+package election
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"oxia/oxia"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrorAlreadyCampaigning This instance is already a candidate in the election
+	ErrorAlreadyCampaigning = errors.New("oxia election: already campaigning")
+
+	// ErrorNotLeader This instance is not the leader of the election
+	ErrorNotLeader = errors.New("oxia election: not the leader")
+
+	// ErrorLeadershipLost The record of the candidate was deleted, typically because the
+	// session of the client has expired
+	ErrorLeadershipLost = errors.New("oxia election: leadership was lost")
+
+	// ErrorElectionClosed The election instance was already closed
+	ErrorElectionClosed = errors.New("oxia election: election is closed")
+)
+
+// defaultDeleteTimeout bounds the removal of a candidate that gave up campaigning
+const defaultDeleteTimeout = 30 * time.Second
+
+// Leader describes the current leader of an election.
+type Leader struct {
+	// Key is the key of the record of the leader. It's empty when there is
+	// no leader
+	Key string
+
+	// Value is the value that the leader passed to [Election.Campaign]
+	Value []byte
+
+	// VersionId of the record of the leader. It increases with each new leader,
+	// and it can be used as fencing token
+	VersionId int64
+}
+
+// Election is a leader election among the instances that share the same name.
+type Election interface {
+	// Campaign enters the election with the given value and waits until this
+	// instance is elected leader, or the context is done.
+	Campaign(ctx context.Context, value []byte) error
+
+	// Resign gives up the leadership, letting the next candidate be elected.
+	// Returns ErrorNotLeader if this instance is not the leader.
+	Resign(ctx context.Context) error
+
+	// IsLeader returns true while this instance is the leader.
+	IsLeader() bool
+
+	// Leader returns the current leader of the election.
+	Leader() Leader
+
+	// LeaderChanges returns a channel that receives the current leader each
+	// time it changes. Only the most recent leader is kept, if the receiver
+	// is slower than the changes.
+	// The channel is closed when the election is closed.
+	LeaderChanges() <-chan Leader
+
+	// Close stops observing the election, after resigning if this instance
+	// is the leader, or leaving the queue of the candidates. A pending
+	// Campaign returns ErrorElectionClosed.
+	Close() error
+}
+
+// Option configures an election.
+type Option interface {
+	apply(options options) options
+}
+
+type options struct {
+	onLeadershipLost func()
+}
+
+type optionFunc func(options) options
+
+func (f optionFunc) apply(o options) options {
+	return f(o)
+}
+
+// OnLeadershipLost sets a callback that is invoked when this instance loses
+// the leadership without resigning, typically because the session of the client
+// has expired or could not be kept alive. Leader-only work should be stopped
+// when the callback fires.
+func OnLeadershipLost(callback func()) Option {
+	return optionFunc(func(o options) options {
+		o.onLeadershipLost = callback
+		return o
+	})
+}
+
+type election struct {
+	mutex   sync.Mutex
+	client  oxia.SyncClient
+	name    string
+	prefix  string
+	options options
+	log     zerolog.Logger
+
+	notifications oxia.Notifications
+	leaderCh      chan Leader
+	leader        Leader
+	// changed is closed, and replaced, each time the candidates are updated
+	changed chan struct{}
+
+	key      string
+	isLeader bool
+	// candidacyDone is closed when the candidacy of this instance ends
+	candidacyDone chan struct{}
+
+	closed      bool
+	initialized bool
+}
+
+// NewElection creates an election that is identified by the given name, and
+// starts observing its leader.
+//
+// The records of the candidates are stored under `<name>/candidate-`, in the
+// shard of the name.
+func NewElection(client oxia.SyncClient, name string, opts ...Option) (Election, error) {
+	e := &election{
+		client:   client,
+		name:     name,
+		prefix:   name + "/candidate",
+		leaderCh: make(chan Leader, 1),
+		changed:  make(chan struct{}),
+		log: log.With().
+			Str("component", "oxia-election").
+			Str("election", name).
+			Logger(),
+	}
+	for _, o := range opts {
+		e.options = o.apply(e.options)
+	}
+
+	var err error
+	if e.notifications, err = client.GetNotifications(oxia.FilterKeyPrefix(e.prefix + "-")); err != nil {
+		return nil, err
+	}
+
+	if err = e.refresh(); err != nil {
+		_ = e.notifications.Close()
+		return nil, err
+	}
+
+	go e.observe()
+	return e, nil
+}
+
+func (e *election) Campaign(ctx context.Context, value []byte) error {
+	e.mutex.Lock()
+	if e.closed {
+		e.mutex.Unlock()
+		return ErrorElectionClosed
+	}
+	if e.key != "" {
+		e.mutex.Unlock()
+		return ErrorAlreadyCampaigning
+	}
+	// Reserve the candidacy while the record is being created
+	e.key = e.prefix
+	e.mutex.Unlock()
+
+	// The record of the candidate is bound to the session of the shard
+	sessionDone, err := e.client.SessionDone(ctx, e.name)
+	if err != nil {
+		e.clearCandidacy(e.prefix)
+		return err
+	}
+
+	version, err := e.client.Put(ctx, e.prefix, value,
+		oxia.Ephemeral(), oxia.PartitionKey(e.name), oxia.SequentialKeysDeltas(1))
+	if err != nil {
+		e.clearCandidacy(e.prefix)
+		return err
+	}
+
+	key := version.Key
+	candidacyDone := make(chan struct{})
+	e.mutex.Lock()
+	if e.closed {
+		// The election was closed while the record was being created
+		e.mutex.Unlock()
+		e.leave(key)
+		return ErrorElectionClosed
+	}
+	e.key = key
+	e.candidacyDone = candidacyDone
+	e.mutex.Unlock()
+
+	go e.watchSession(sessionDone, candidacyDone, key)
+
+	if err = e.waitForElection(ctx, key); err != nil {
+		e.clearCandidacy(key)
+		e.leave(key)
+		return err
+	}
+
+	e.log.Info().
+		Str("key", key).
+		Int64("version-id", version.VersionId).
+		Msg("Elected leader")
+	return nil
+}
+
+// waitForElection waits until the candidate is the first in the queue
+func (e *election) waitForElection(ctx context.Context, key string) error {
+	for {
+		// Take the channel before reading the candidates, so that no update
+		// can be missed
+		e.mutex.Lock()
+		if e.closed {
+			e.mutex.Unlock()
+			return ErrorElectionClosed
+		}
+		if e.key != key {
+			e.mutex.Unlock()
+			return ErrorLeadershipLost
+		}
+		changed := e.changed
+		candidacyDone := e.candidacyDone
+		e.mutex.Unlock()
+
+		candidates, err := e.candidates(ctx)
+		if err != nil {
+			return err
+		}
+
+		switch indexOf(candidates, key) {
+		case -1:
+			return ErrorLeadershipLost
+		case 0:
+			e.mutex.Lock()
+			defer e.mutex.Unlock()
+			if e.closed {
+				return ErrorElectionClosed
+			}
+			if e.key != key {
+				// The record was deleted in the meantime
+				return ErrorLeadershipLost
+			}
+			e.isLeader = true
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-candidacyDone:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// leave removes the record of the candidate from the queue, logging the
+// failures. The context of the campaign might be already done at this point.
+func (e *election) leave(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultDeleteTimeout)
+	defer cancel()
+	if err := e.deleteRecord(ctx, key); err != nil {
+		e.log.Warn().Err(err).
+			Str("key", key).
+			Msg("Failed to remove the election candidate")
+	}
+}
+
+// deleteRecord deletes the record of the candidate, which might have already
+// been removed with the session
+func (e *election) deleteRecord(ctx context.Context, key string) error {
+	err := e.client.Delete(ctx, key, oxia.PartitionKey(e.name))
+	if errors.Is(err, oxia.ErrorKeyNotFound) {
+		return nil
+	}
+	return err
+}
+
+// watchSession ends the candidacy once the session of the client is lost, since
+// the record of the candidate is deleted with it
+func (e *election) watchSession(sessionDone <-chan struct{}, candidacyDone <-chan struct{}, key string) {
+	select {
+	case <-sessionDone:
+		e.checkDeleted(key)
+	case <-candidacyDone:
+	}
+}
+
+func (e *election) clearCandidacy(key string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.key == key {
+		e.endCandidacy()
+	}
+}
+
+// endCandidacy must be called with the mutex held
+func (e *election) endCandidacy() {
+	e.key = ""
+	e.isLeader = false
+	if e.candidacyDone != nil {
+		close(e.candidacyDone)
+		e.candidacyDone = nil
+	}
+}
+
+func (e *election) Resign(ctx context.Context) error {
+	e.mutex.Lock()
+	key := e.key
+	isLeader := e.isLeader
+	if isLeader {
+		e.endCandidacy()
+	}
+	e.mutex.Unlock()
+
+	if !isLeader {
+		return ErrorNotLeader
+	}
+
+	e.log.Info().
+		Str("key", key).
+		Msg("Resigning leadership")
+
+	return errors.Wrap(e.deleteRecord(ctx, key), "oxia election: failed to resign")
+}
+
+func (e *election) IsLeader() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.isLeader
+}
+
+func (e *election) Leader() Leader {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.leader
+}
+
+func (e *election) LeaderChanges() <-chan Leader {
+	return e.leaderCh
+}
+
+func (e *election) Close() error {
+	e.mutex.Lock()
+	if e.closed {
+		e.mutex.Unlock()
+		return nil
+	}
+	e.closed = true
+	key := e.key
+	isLeader := e.isLeader
+	if key != "" {
+		// Stop the campaign, if any, and resign
+		e.endCandidacy()
+	}
+	e.mutex.Unlock()
+
+	var err error
+	if key != "" && key != e.prefix {
+		if isLeader {
+			e.log.Info().
+				Str("key", key).
+				Msg("Resigning leadership")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultDeleteTimeout)
+		defer cancel()
+		err = errors.Wrap(e.deleteRecord(ctx, key), "oxia election: failed to leave the election")
+	}
+
+	if closeErr := e.notifications.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// observe keeps track of the leader, and detects the deletion of the record of
+// this instance
+func (e *election) observe() {
+	defer close(e.leaderCh)
+
+	for n := range e.notifications.Ch() {
+		if n.Type == oxia.KeyDeleted {
+			e.checkDeleted(n.Key)
+		}
+
+		if err := e.refresh(); err != nil {
+			e.log.Warn().Err(err).
+				Msg("Failed to read the election candidates")
+		}
+	}
+
+	e.mutex.Lock()
+	closed := e.closed
+	e.mutex.Unlock()
+
+	if !closed {
+		// Without notifications, the deletion of the record cannot be
+		// detected anymore
+		e.log.Warn().Err(e.notifications.Err()).
+			Msg("Stopped observing the election")
+		e.checkDeleted("")
+	}
+
+	// Wake up the campaign, if any
+	e.mutex.Lock()
+	close(e.changed)
+	e.mutex.Unlock()
+}
+
+// checkDeleted handles the deletion of a candidate record. An empty key means
+// that the record of this instance is not observable anymore
+func (e *election) checkDeleted(key string) {
+	e.mutex.Lock()
+	if e.key == "" || (key != "" && key != e.key) {
+		e.mutex.Unlock()
+		return
+	}
+
+	wasLeader := e.isLeader
+	lostKey := e.key
+	e.endCandidacy()
+	e.mutex.Unlock()
+
+	if wasLeader {
+		e.log.Warn().
+			Str("key", lostKey).
+			Msg("Leadership was lost")
+		if e.options.onLeadershipLost != nil {
+			e.options.onLeadershipLost()
+		}
+	}
+}
+
+// refresh reads the current leader and publishes it, if it has changed
+func (e *election) refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultDeleteTimeout)
+	defer cancel()
+
+	candidates, err := e.candidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	leader := Leader{}
+	for _, candidate := range candidates {
+		value, version, err := e.client.Get(ctx, candidate, oxia.PartitionKey(e.name))
+		if errors.Is(err, oxia.ErrorKeyNotFound) {
+			// The candidate has left in the meantime
+			continue
+		} else if err != nil {
+			return err
+		}
+		leader = Leader{Key: candidate, Value: value, VersionId: version.VersionId}
+		break
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	close(e.changed)
+	e.changed = make(chan struct{})
+
+	if e.initialized && e.leader.Key == leader.Key {
+		return nil
+	}
+	e.initialized = true
+	e.leader = leader
+
+	// Replace the previous leader, if it was not received yet. This is the
+	// only sender on the channel
+	select {
+	case e.leaderCh <- leader:
+	default:
+		select {
+		case <-e.leaderCh:
+		default:
+		}
+		e.leaderCh <- leader
+	}
+	return nil
+}
+
+func (e *election) candidates(ctx context.Context) ([]string, error) {
+	var keys []string
+	for r := range e.client.List(ctx, e.prefix+"-", e.prefix+".", oxia.PartitionKey(e.name)) {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		keys = append(keys, r.Keys...)
+	}
+	return keys, nil
+}
+
+func indexOf(keys []string, key string) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
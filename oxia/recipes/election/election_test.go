@@ -0,0 +1,245 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package election
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"oxia/oxia"
+	"oxia/server"
+	"testing"
+	"time"
+)
+
+func newClient(t *testing.T, serviceAddress string) oxia.SyncClient {
+	t.Helper()
+	client, err := oxia.NewSyncClient(serviceAddress, oxia.WithSessionTimeout(5*time.Second))
+	assert.NoError(t, err)
+	return client
+}
+
+func nextLeader(t *testing.T, e Election) Leader {
+	t.Helper()
+	select {
+	case leader := <-e.LeaderChanges():
+		return leader
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "leader change not received")
+		return Leader{}
+	}
+}
+
+func TestElection_CampaignResign(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	e, err := NewElection(client, "/my-election")
+	assert.NoError(t, err)
+
+	// The initial state has no leader
+	assert.Equal(t, Leader{}, nextLeader(t, e))
+	assert.False(t, e.IsLeader())
+	assert.ErrorIs(t, e.Resign(ctx), ErrorNotLeader)
+
+	assert.NoError(t, e.Campaign(ctx, []byte("node-1")))
+	assert.True(t, e.IsLeader())
+	assert.ErrorIs(t, e.Campaign(ctx, []byte("node-1")), ErrorAlreadyCampaigning)
+
+	leader := nextLeader(t, e)
+	assert.Equal(t, "node-1", string(leader.Value))
+	assert.Equal(t, leader, e.Leader())
+
+	assert.NoError(t, e.Resign(ctx))
+	assert.False(t, e.IsLeader())
+	assert.Equal(t, Leader{}, nextLeader(t, e))
+
+	assert.NoError(t, e.Close())
+	_, more := <-e.LeaderChanges()
+	assert.False(t, more)
+	assert.ErrorIs(t, e.Campaign(ctx, []byte("node-1")), ErrorElectionClosed)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestElection_Failover(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client1 := newClient(t, serviceAddress)
+	client2 := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	lostCh := make(chan struct{})
+	e1, err := NewElection(client1, "/my-election", OnLeadershipLost(func() {
+		close(lostCh)
+	}))
+	assert.NoError(t, err)
+	e2, err := NewElection(client2, "/my-election")
+	assert.NoError(t, err)
+
+	assert.NoError(t, e1.Campaign(ctx, []byte("node-1")))
+	assert.Eventually(t, func() bool {
+		return string(e2.Leader().Value) == "node-1"
+	}, 10*time.Second, 10*time.Millisecond)
+	firstLeader := e2.Leader()
+
+	electedCh := make(chan error, 1)
+	go func() {
+		electedCh <- e2.Campaign(ctx, []byte("node-2"))
+	}()
+
+	// The second candidate is waiting for the leader to go away
+	time.Sleep(500 * time.Millisecond)
+	assert.False(t, e2.IsLeader())
+
+	// Closing the client of the leader terminates its session
+	assert.NoError(t, client1.Close())
+
+	select {
+	case err := <-electedCh:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "second candidate was not elected")
+	}
+
+	select {
+	case <-lostCh:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "leadership lost callback was not invoked")
+	}
+	assert.False(t, e1.IsLeader())
+
+	assert.True(t, e2.IsLeader())
+	assert.Eventually(t, func() bool {
+		return string(e2.Leader().Value) == "node-2"
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Greater(t, e2.Leader().VersionId, firstLeader.VersionId)
+
+	assert.NoError(t, e2.Close())
+	assert.NoError(t, client2.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestElection_CampaignCancelled(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client := newClient(t, serviceAddress)
+
+	e1, err := NewElection(client, "/my-election")
+	assert.NoError(t, err)
+	e2, err := NewElection(client, "/my-election")
+	assert.NoError(t, err)
+
+	assert.NoError(t, e1.Campaign(context.Background(), []byte("node-1")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	assert.ErrorIs(t, e2.Campaign(ctx, []byte("node-2")), context.DeadlineExceeded)
+	assert.False(t, e2.IsLeader())
+
+	// The cancelled candidate has left the election
+	assert.NoError(t, e1.Resign(context.Background()))
+	assert.Eventually(t, func() bool {
+		return e2.Leader().Key == ""
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, e1.Close())
+	assert.NoError(t, e2.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestElection_CloseDuringCampaign(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	e1, err := NewElection(client, "/my-election")
+	assert.NoError(t, err)
+	e2, err := NewElection(client, "/my-election")
+	assert.NoError(t, err)
+
+	assert.NoError(t, e1.Campaign(ctx, []byte("node-1")))
+
+	electedCh := make(chan error, 1)
+	go func() {
+		electedCh <- e2.Campaign(ctx, []byte("node-2"))
+	}()
+
+	// The second candidate is waiting for the leader to go away
+	time.Sleep(500 * time.Millisecond)
+	assert.NoError(t, e2.Close())
+
+	select {
+	case err := <-electedCh:
+		assert.ErrorIs(t, err, ErrorElectionClosed)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "campaign did not return after close")
+	}
+	assert.False(t, e2.IsLeader())
+
+	// The closed candidate has left the election
+	assert.NoError(t, e1.Resign(ctx))
+	assert.Eventually(t, func() bool {
+		return e1.Leader().Key == ""
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, e1.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestElection_LostWithKeepAliveFailure(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client, err := oxia.NewSyncClient(serviceAddress, oxia.WithSessionTimeout(2*time.Second))
+	assert.NoError(t, err)
+
+	lost := make(chan struct{})
+	e, err := NewElection(client, "/my-election", OnLeadershipLost(func() {
+		close(lost)
+	}))
+	assert.NoError(t, err)
+	assert.NoError(t, e.Campaign(context.Background(), []byte("node-1")))
+	assert.True(t, e.IsLeader())
+
+	// Once the server is gone, the keep-alives fail and the session is lost,
+	// even though no notification can be received
+	assert.NoError(t, standaloneServer.Close())
+
+	select {
+	case <-lost:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "leadership should be lost")
+	}
+	assert.False(t, e.IsLeader())
+
+	assert.NoError(t, e.Close())
+	assert.NoError(t, client.Close())
+}
@@ -0,0 +1,337 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lock implements a distributed lock on top of Oxia ephemeral records.
+//
+// Each contender appends an ephemeral record with a sequential key to the queue
+// of the lock, and the lock is granted in the order of the keys. Every contender
+// only watches its predecessor in the queue, so that a release wakes up a single
+// waiter. Since the records are ephemeral, a lock is automatically released when
+// the session of its holder expires, and the holder considers the lock lost as
+// soon as the client reports the loss of the session.
+package lock
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"oxia/oxia"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrorLockAlreadyHeld The lock is already held, or being acquired, through this instance
+	ErrorLockAlreadyHeld = errors.New("oxia lock: lock is already held")
+
+	// ErrorLockNotHeld The lock is not held through this instance
+	ErrorLockNotHeld = errors.New("oxia lock: lock is not held")
+
+	// ErrorLockLost The record of the contender was deleted, or the session of the
+	// client was lost
+	ErrorLockLost = errors.New("oxia lock: lock was lost")
+)
+
+// defaultDeleteTimeout bounds the removal of a contender that gave up waiting
+const defaultDeleteTimeout = 30 * time.Second
+
+// Lock is a distributed mutual exclusion lock, identified by a key.
+//
+// The lock is granted to the contenders in the order of their requests. While
+// the lock is held, the [Lock.FencingToken] can be passed along with the writes
+// to other systems, so that they can reject the writes of former holders.
+type Lock interface {
+	// Lock acquires the lock, waiting until it becomes available or the context
+	// is done.
+	Lock(ctx context.Context) error
+
+	// TryLock acquires the lock only if it is available, without waiting.
+	// Returns true if the lock was acquired.
+	TryLock(ctx context.Context) (bool, error)
+
+	// Unlock releases the lock.
+	// Returns ErrorLockNotHeld if the lock is not held.
+	Unlock(ctx context.Context) error
+
+	// FencingToken returns a token that is strictly increasing with each
+	// acquisition of the lock, or -1 if the lock is not held.
+	FencingToken() int64
+
+	// Done returns a channel that is closed when the lock is no longer held,
+	// either because it was released, or because it was lost with the session
+	// of the client.
+	Done() <-chan struct{}
+}
+
+type lock struct {
+	mutex  sync.Mutex
+	client oxia.SyncClient
+	name   string
+	prefix string
+	log    zerolog.Logger
+
+	acquiring     bool
+	key           string
+	fencingToken  int64
+	done          chan struct{}
+	notifications oxia.Notifications
+}
+
+// NewLock creates a lock that is identified by the given key.
+//
+// The records of the contenders are stored under `<key>/contender-`, in the
+// shard of the key.
+func NewLock(client oxia.SyncClient, key string) Lock {
+	done := make(chan struct{})
+	close(done)
+	return &lock{
+		client:       client,
+		name:         key,
+		prefix:       key + "/contender",
+		fencingToken: -1,
+		done:         done,
+		log: log.With().
+			Str("component", "oxia-lock").
+			Str("lock", key).
+			Logger(),
+	}
+}
+
+func (l *lock) Lock(ctx context.Context) error {
+	_, err := l.acquire(ctx, true)
+	return err
+}
+
+func (l *lock) TryLock(ctx context.Context) (bool, error) {
+	return l.acquire(ctx, false)
+}
+
+func (l *lock) acquire(ctx context.Context, wait bool) (bool, error) {
+	l.mutex.Lock()
+	if l.acquiring || l.key != "" {
+		l.mutex.Unlock()
+		return false, ErrorLockAlreadyHeld
+	}
+	l.acquiring = true
+	l.mutex.Unlock()
+
+	defer func() {
+		l.mutex.Lock()
+		l.acquiring = false
+		l.mutex.Unlock()
+	}()
+
+	// Subscribe before joining the queue, so that no release can be missed
+	notifications, err := l.client.GetNotifications(oxia.FilterKeyPrefix(l.prefix + "-"))
+	if err != nil {
+		return false, err
+	}
+
+	// The record of the contender is bound to the session of the shard
+	sessionDone, err := l.client.SessionDone(ctx, l.name)
+	if err != nil {
+		_ = notifications.Close()
+		return false, err
+	}
+
+	version, err := l.client.Put(ctx, l.prefix, []byte{},
+		oxia.Ephemeral(), oxia.PartitionKey(l.name), oxia.SequentialKeysDeltas(1))
+	if err != nil {
+		_ = notifications.Close()
+		return false, err
+	}
+
+	key := version.Key
+	acquired, err := l.waitForTurn(ctx, notifications, sessionDone, key, wait)
+	if err != nil || !acquired {
+		_ = notifications.Close()
+
+		// Leave the queue. The context might be already done at this point
+		deleteCtx, cancel := context.WithTimeout(context.Background(), defaultDeleteTimeout)
+		defer cancel()
+		if deleteErr := l.client.Delete(deleteCtx, key, oxia.PartitionKey(l.name)); deleteErr != nil &&
+			!errors.Is(deleteErr, oxia.ErrorKeyNotFound) {
+			l.log.Warn().Err(deleteErr).
+				Str("key", key).
+				Msg("Failed to remove the lock contender")
+		}
+		return false, err
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.key = key
+	l.fencingToken = version.VersionId
+	l.notifications = notifications
+	l.done = make(chan struct{})
+
+	l.log.Debug().
+		Str("key", key).
+		Int64("fencing-token", l.fencingToken).
+		Msg("Lock acquired")
+
+	go l.watch(notifications, sessionDone, key, l.done)
+	return true, nil
+}
+
+// waitForTurn waits until the contender is the first in the queue
+func (l *lock) waitForTurn(ctx context.Context, notifications oxia.Notifications, sessionDone <-chan struct{},
+	key string, wait bool) (bool, error) {
+	for {
+		contenders, err := l.contenders(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		idx := indexOf(contenders, key)
+		switch {
+		case idx < 0:
+			return false, ErrorLockLost
+		case idx == 0:
+			return true, nil
+		case !wait:
+			return false, nil
+		}
+
+		if err := waitForDeletion(ctx, notifications, sessionDone, contenders[idx-1], key); err != nil {
+			return false, err
+		}
+	}
+}
+
+// waitForDeletion waits until the predecessor record is deleted
+func waitForDeletion(ctx context.Context, notifications oxia.Notifications, sessionDone <-chan struct{},
+	predecessor string, key string) error {
+	for {
+		select {
+		case n, more := <-notifications.Ch():
+			if !more {
+				if err := notifications.Err(); err != nil {
+					return err
+				}
+				return ErrorLockLost
+			}
+			if n.Type != oxia.KeyDeleted {
+				continue
+			}
+			if n.Key == predecessor {
+				return nil
+			} else if n.Key == key {
+				return ErrorLockLost
+			}
+
+		case <-sessionDone:
+			return ErrorLockLost
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// watch closes the done channel once the record of the holder is deleted, or
+// the session of the client is lost
+func (l *lock) watch(notifications oxia.Notifications, sessionDone <-chan struct{}, key string, done chan struct{}) {
+	for lost := false; !lost; {
+		select {
+		case n, more := <-notifications.Ch():
+			lost = !more || (n.Type == oxia.KeyDeleted && n.Key == key)
+		case <-sessionDone:
+			lost = true
+		}
+	}
+
+	l.mutex.Lock()
+	lost := l.key == key
+	if lost {
+		l.log.Warn().
+			Str("key", key).
+			Msg("Lock was lost")
+		l.key = ""
+		l.fencingToken = -1
+		l.notifications = nil
+	}
+	l.mutex.Unlock()
+
+	close(done)
+	if lost {
+		// Otherwise, the subscription is closed by Unlock
+		_ = notifications.Close()
+	}
+}
+
+func (l *lock) contenders(ctx context.Context) ([]string, error) {
+	var keys []string
+	for r := range l.client.List(ctx, l.prefix+"-", l.prefix+".", oxia.PartitionKey(l.name)) {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		keys = append(keys, r.Keys...)
+	}
+	return keys, nil
+}
+
+func (l *lock) Unlock(ctx context.Context) error {
+	l.mutex.Lock()
+	key := l.key
+	notifications := l.notifications
+	l.key = ""
+	l.fencingToken = -1
+	l.notifications = nil
+	l.mutex.Unlock()
+
+	if key == "" {
+		if notifications != nil {
+			_ = notifications.Close()
+		}
+		return ErrorLockNotHeld
+	}
+
+	err := l.client.Delete(ctx, key, oxia.PartitionKey(l.name))
+	if errors.Is(err, oxia.ErrorKeyNotFound) {
+		// The record was already removed with the session
+		err = nil
+	}
+
+	return errors.Wrap(multiClose(err, notifications), "oxia lock: failed to release the lock")
+}
+
+func (l *lock) FencingToken() int64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.fencingToken
+}
+
+func (l *lock) Done() <-chan struct{} {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.done
+}
+
+func multiClose(err error, notifications oxia.Notifications) error {
+	if closeErr := notifications.Close(); err == nil {
+		return closeErr
+	}
+	return err
+}
+
+func indexOf(keys []string, key string) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"oxia/oxia"
+	"oxia/server"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newClient(t *testing.T, serviceAddress string) oxia.SyncClient {
+	t.Helper()
+	client, err := oxia.NewSyncClient(serviceAddress, oxia.WithSessionTimeout(5*time.Second))
+	assert.NoError(t, err)
+	return client
+}
+
+func listContenders(ctx context.Context, client oxia.SyncClient) ([]string, error) {
+	var keys []string
+	for r := range client.List(ctx, "/my-lock/contender-", "/my-lock/contender.", oxia.PartitionKey("/my-lock")) {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		keys = append(keys, r.Keys...)
+	}
+	return keys, nil
+}
+
+func TestLock_LockUnlock(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	l := NewLock(client, "/my-lock")
+	assert.EqualValues(t, -1, l.FencingToken())
+	assert.ErrorIs(t, l.Unlock(ctx), ErrorLockNotHeld)
+
+	assert.NoError(t, l.Lock(ctx))
+	token := l.FencingToken()
+	assert.GreaterOrEqual(t, token, int64(0))
+	assert.ErrorIs(t, l.Lock(ctx), ErrorLockAlreadyHeld)
+
+	done := l.Done()
+	select {
+	case <-done:
+		assert.Fail(t, "lock should be held")
+	default:
+	}
+
+	assert.NoError(t, l.Unlock(ctx))
+	assert.EqualValues(t, -1, l.FencingToken())
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "done channel should be closed")
+	}
+
+	// Lock again, with a new fencing token
+	assert.NoError(t, l.Lock(ctx))
+	assert.Greater(t, l.FencingToken(), token)
+	assert.NoError(t, l.Unlock(ctx))
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestLock_TryLock(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client1 := newClient(t, serviceAddress)
+	client2 := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	l1 := NewLock(client1, "/my-lock")
+	l2 := NewLock(client2, "/my-lock")
+
+	acquired, err := l1.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = l2.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+	assert.EqualValues(t, -1, l2.FencingToken())
+
+	// The failed attempt must have left the queue
+	keys, err := listContenders(ctx, client1)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	assert.NoError(t, l1.Unlock(ctx))
+
+	acquired, err = l2.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	assert.NoError(t, l2.Unlock(ctx))
+
+	assert.NoError(t, client1.Close())
+	assert.NoError(t, client2.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestLock_FairQueueing(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	holder := NewLock(client, "/my-lock")
+	assert.NoError(t, holder.Lock(ctx))
+
+	// Enqueue the waiters one at a time, so that their order is known
+	const waiters = 3
+	var order []int
+	orderMutex := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(waiters)
+	for i := 0; i < waiters; i++ {
+		i := i
+		l := NewLock(client, "/my-lock")
+		go func() {
+			defer wg.Done()
+			if !assert.NoError(t, l.Lock(ctx)) {
+				return
+			}
+			orderMutex.Lock()
+			order = append(order, i)
+			orderMutex.Unlock()
+			assert.NoError(t, l.Unlock(ctx))
+		}()
+
+		assert.Eventually(t, func() bool {
+			keys, err := listContenders(ctx, client)
+			return err == nil && len(keys) == i+2
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+
+	assert.NoError(t, holder.Unlock(ctx))
+
+	wg.Wait()
+	assert.Equal(t, []int{0, 1, 2}, order)
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestLock_ReleasedWithSession(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client1 := newClient(t, serviceAddress)
+	client2 := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	l1 := NewLock(client1, "/my-lock")
+	assert.NoError(t, l1.Lock(ctx))
+	token := l1.FencingToken()
+
+	l2 := NewLock(client2, "/my-lock")
+	acquiredCh := make(chan error, 1)
+	go func() {
+		acquiredCh <- l2.Lock(ctx)
+	}()
+
+	// Closing the client of the holder terminates its session
+	assert.NoError(t, client1.Close())
+
+	select {
+	case err := <-acquiredCh:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "lock was not acquired")
+	}
+
+	assert.Greater(t, l2.FencingToken(), token)
+
+	select {
+	case <-l1.Done():
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "lock should be lost")
+	}
+
+	assert.NoError(t, l2.Unlock(ctx))
+	assert.NoError(t, client2.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestLock_ContextCancelled(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client := newClient(t, serviceAddress)
+
+	l1 := NewLock(client, "/my-lock")
+	assert.NoError(t, l1.Lock(context.Background()))
+
+	l2 := NewLock(client, "/my-lock")
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	assert.ErrorIs(t, l2.Lock(ctx), context.DeadlineExceeded)
+
+	keys, err := listContenders(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	assert.NoError(t, l1.Unlock(context.Background()))
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestLock_LostWithKeepAliveFailure(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client, err := oxia.NewSyncClient(serviceAddress, oxia.WithSessionTimeout(2*time.Second))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	l := NewLock(client, "/my-lock")
+	assert.NoError(t, l.Lock(ctx))
+
+	// Once the server is gone, the keep-alives fail and the session is lost,
+	// even though no notification can be received
+	assert.NoError(t, standaloneServer.Close())
+
+	select {
+	case <-l.Done():
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "lock should be lost")
+	}
+
+	assert.EqualValues(t, -1, l.FencingToken())
+	assert.ErrorIs(t, l.Unlock(ctx), ErrorLockNotHeld)
+	assert.NoError(t, client.Close())
+}
@@ -0,0 +1,510 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package membership implements service discovery and group membership on top
+// of Oxia ephemeral records.
+//
+// Each member of a group is stored as an ephemeral record under the prefix of
+// the group, with its metadata as value. The view of the group is loaded with
+// a listing, and then it is kept up to date with the notifications. If the
+// notifications are interrupted, the view is reconciled with a new listing.
+package membership
+
+import (
+	"context"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+	"oxia/common"
+	"oxia/oxia"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrorInvalidMemberId The id of a member must be non-empty and cannot contain `/`
+	ErrorInvalidMemberId = errors.New("oxia membership: invalid member id")
+
+	// ErrorAlreadyRegistered This instance has already registered a member
+	ErrorAlreadyRegistered = errors.New("oxia membership: already registered")
+
+	// ErrorNotRegistered This instance has not registered a member
+	ErrorNotRegistered = errors.New("oxia membership: not registered")
+
+	// ErrorGroupClosed The group instance was already closed
+	ErrorGroupClosed = errors.New("oxia membership: group is closed")
+)
+
+// defaultReadTimeout bounds the reads done to reconcile the view of the group
+const defaultReadTimeout = 30 * time.Second
+
+// EventType is the type of change in the membership of a group.
+type EventType int
+
+const (
+	// MemberJoined A member was added to the group
+	MemberJoined EventType = iota
+
+	// MemberUpdated The metadata of a member has changed
+	MemberUpdated
+
+	// MemberLeft A member was removed from the group, either because it
+	// deregistered or because its session expired
+	MemberLeft
+)
+
+func (e EventType) String() string {
+	switch e {
+	case MemberJoined:
+		return "MemberJoined"
+	case MemberUpdated:
+		return "MemberUpdated"
+	case MemberLeft:
+		return "MemberLeft"
+	}
+
+	return "Unknown"
+}
+
+// Member is a member of a group.
+type Member struct {
+	// Id is the unique identifier of the member within the group
+	Id string
+
+	// Metadata is the value registered by the member
+	Metadata []byte
+
+	// VersionId of the record of the member
+	VersionId int64
+}
+
+// Event is a change in the membership of a group.
+type Event struct {
+	// Type of the change
+	Type EventType
+
+	// Member that has changed. For a MemberLeft event, it is the last known
+	// state of the member
+	Member Member
+}
+
+// Group is a live view of the members of a group, which can also be used to
+// register a member.
+type Group interface {
+	// Register adds a member to the group, with the given id and metadata.
+	// The member is removed from the group when the session of the client
+	// expires.
+	Register(ctx context.Context, id string, metadata []byte) error
+
+	// UpdateMetadata replaces the metadata of the member registered through
+	// this instance.
+	UpdateMetadata(ctx context.Context, metadata []byte) error
+
+	// Deregister removes the member registered through this instance.
+	Deregister(ctx context.Context) error
+
+	// Members returns the current members of the group, sorted by id.
+	Members() []Member
+
+	// Events returns a channel that receives the changes in the membership.
+	// The channel first receives a MemberJoined event for each of the initial
+	// members. Events are queued until they are received, and the channel is
+	// closed when the group is closed.
+	Events() <-chan Event
+
+	// Close stops following the group, after deregistering the member
+	// registered through this instance, if any.
+	Close() error
+}
+
+type group struct {
+	mutex  sync.Mutex
+	client oxia.SyncClient
+	prefix string
+	log    zerolog.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	members      map[string]Member
+	registeredId string
+	closed       bool
+
+	pending   []Event
+	pendingCh chan struct{}
+	eventsCh  chan Event
+	wg        sync.WaitGroup
+}
+
+// NewGroup creates a view of the group with the given prefix. The members are
+// stored under `<prefix>/<id>`.
+func NewGroup(client oxia.SyncClient, prefix string) (Group, error) {
+	g := &group{
+		client:    client,
+		prefix:    prefix + "/",
+		members:   map[string]Member{},
+		pendingCh: make(chan struct{}, 1),
+		eventsCh:  make(chan Event),
+		log: log.With().
+			Str("component", "oxia-membership").
+			Str("group", prefix).
+			Logger(),
+	}
+	g.ctx, g.cancel = context.WithCancel(context.Background())
+
+	notifications, err := g.subscribe()
+	if err != nil {
+		g.cancel()
+		return nil, err
+	}
+
+	// Load the initial view, so that it's available as soon as the group is
+	// returned
+	if err = g.reconcile(); err != nil {
+		g.cancel()
+		return nil, multierr.Combine(err, notifications.Close())
+	}
+
+	g.wg.Add(2)
+	go g.follow(notifications)
+	go g.dispatch()
+	return g, nil
+}
+
+func (g *group) key(id string) string {
+	return g.prefix + id
+}
+
+func (g *group) Register(ctx context.Context, id string, metadata []byte) error {
+	if id == "" || strings.Contains(id, "/") {
+		return ErrorInvalidMemberId
+	}
+
+	g.mutex.Lock()
+	if g.closed {
+		g.mutex.Unlock()
+		return ErrorGroupClosed
+	}
+	if g.registeredId != "" {
+		g.mutex.Unlock()
+		return ErrorAlreadyRegistered
+	}
+	g.registeredId = id
+	g.mutex.Unlock()
+
+	if _, err := g.client.Put(ctx, g.key(id), metadata, oxia.Ephemeral()); err != nil {
+		g.mutex.Lock()
+		g.registeredId = ""
+		g.mutex.Unlock()
+		return err
+	}
+
+	g.log.Info().
+		Str("member", id).
+		Msg("Registered member")
+	return nil
+}
+
+func (g *group) UpdateMetadata(ctx context.Context, metadata []byte) error {
+	g.mutex.Lock()
+	id := g.registeredId
+	g.mutex.Unlock()
+
+	if id == "" {
+		return ErrorNotRegistered
+	}
+
+	_, err := g.client.Put(ctx, g.key(id), metadata, oxia.Ephemeral())
+	return err
+}
+
+func (g *group) Deregister(ctx context.Context) error {
+	g.mutex.Lock()
+	id := g.registeredId
+	g.registeredId = ""
+	g.mutex.Unlock()
+
+	if id == "" {
+		return ErrorNotRegistered
+	}
+
+	err := g.client.Delete(ctx, g.key(id))
+	if errors.Is(err, oxia.ErrorKeyNotFound) {
+		// The record was already removed with the session
+		return nil
+	}
+	return err
+}
+
+func (g *group) Members() []Member {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	members := make([]Member, 0, len(g.members))
+	for _, m := range g.members {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Id < members[j].Id
+	})
+	return members
+}
+
+func (g *group) Events() <-chan Event {
+	return g.eventsCh
+}
+
+func (g *group) Close() error {
+	g.mutex.Lock()
+	if g.closed {
+		g.mutex.Unlock()
+		return nil
+	}
+	g.closed = true
+	registered := g.registeredId != ""
+	g.mutex.Unlock()
+
+	var err error
+	if registered {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultReadTimeout)
+		defer cancel()
+		err = g.Deregister(ctx)
+	}
+
+	g.cancel()
+	g.wg.Wait()
+	return err
+}
+
+func (g *group) subscribe() (oxia.Notifications, error) {
+	return g.client.GetNotifications(
+		oxia.FilterKeyPrefix(g.prefix),
+		oxia.IncludeValues(),
+		oxia.IncludePreviousVersion(),
+	)
+}
+
+// follow keeps the view up to date. Every time the notifications are
+// interrupted, a new subscription is created and the view is reconciled
+func (g *group) follow(notifications oxia.Notifications) {
+	defer g.wg.Done()
+
+	for {
+		err := multierr.Combine(g.applyNotifications(notifications), notifications.Close())
+		if g.ctx.Err() != nil {
+			return
+		}
+
+		g.log.Warn().Err(err).
+			Msg("Membership notifications interrupted, reconciling the view of the group")
+
+		if notifications = g.resubscribe(); notifications == nil {
+			return
+		}
+	}
+}
+
+// resubscribe creates a new subscription and reconciles the view with the
+// changes that might have been missed. Returns nil if the group was closed
+func (g *group) resubscribe() oxia.Notifications {
+	var notifications oxia.Notifications
+	err := backoff.RetryNotify(func() error {
+		var err error
+		if notifications, err = g.subscribe(); err != nil {
+			return err
+		}
+
+		// Subscribe before reading the members, so that no change can be missed
+		if err = g.reconcile(); err != nil {
+			return multierr.Combine(err, notifications.Close())
+		}
+		return nil
+	}, common.NewBackOff(g.ctx), func(err error, duration time.Duration) {
+		g.log.Warn().Err(err).
+			Dur("retry-after", duration).
+			Msg("Failed to reconcile the view of the group, retrying later")
+	})
+	if err != nil {
+		return nil
+	}
+	return notifications
+}
+
+func (g *group) applyNotifications(notifications oxia.Notifications) error {
+	for {
+		select {
+		case n, more := <-notifications.Ch():
+			if !more {
+				if err := notifications.Err(); err != nil {
+					return err
+				}
+				return errors.New("oxia membership: notifications channel closed")
+			}
+			if err := g.applyNotification(n); err != nil {
+				return err
+			}
+
+		case <-g.ctx.Done():
+			return g.ctx.Err()
+		}
+	}
+}
+
+func (g *group) applyNotification(n *oxia.Notification) error {
+	id, ok := g.memberId(n.Key)
+	if !ok {
+		return nil
+	}
+
+	if n.Type == oxia.KeyDeleted {
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+		if m, found := g.members[id]; found && (n.PreviousVersionId < 0 || m.VersionId <= n.PreviousVersionId) {
+			delete(g.members, id)
+			g.enqueue(Event{MemberLeft, m})
+		}
+		return nil
+	}
+
+	member := Member{Id: id, Metadata: n.Value, VersionId: n.VersionId}
+	if member.Metadata == nil {
+		// The value exceeded the size limits of the notifications
+		ctx, cancel := context.WithTimeout(g.ctx, defaultReadTimeout)
+		defer cancel()
+
+		value, version, err := g.client.Get(ctx, n.Key)
+		if errors.Is(err, oxia.ErrorKeyNotFound) {
+			// The deletion will follow
+			return nil
+		} else if err != nil {
+			return err
+		}
+		member.Metadata = value
+		member.VersionId = version.VersionId
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.update(member)
+	return nil
+}
+
+// reconcile reloads all the members and emits the events for the differences
+// with the current view
+func (g *group) reconcile() error {
+	ctx, cancel := context.WithTimeout(g.ctx, defaultReadTimeout)
+	defer cancel()
+
+	current := map[string]Member{}
+	for r := range g.client.RangeScan(ctx, g.prefix, g.prefix+"/") {
+		if r.Err != nil {
+			return r.Err
+		}
+		if id, ok := g.memberId(r.Version.Key); ok {
+			current[id] = Member{Id: id, Metadata: r.Value, VersionId: r.Version.VersionId}
+		}
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for id, m := range g.members {
+		if _, found := current[id]; !found {
+			delete(g.members, id)
+			g.enqueue(Event{MemberLeft, m})
+		}
+	}
+
+	ids := make([]string, 0, len(current))
+	for id := range current {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		g.update(current[id])
+	}
+	return nil
+}
+
+// update applies a new state of a member, ignoring the states older than the
+// current one
+func (g *group) update(m Member) {
+	existing, found := g.members[m.Id]
+	switch {
+	case !found:
+		g.members[m.Id] = m
+		g.enqueue(Event{MemberJoined, m})
+	case existing.VersionId < m.VersionId:
+		g.members[m.Id] = m
+		g.enqueue(Event{MemberUpdated, m})
+	}
+}
+
+func (g *group) memberId(key string) (string, bool) {
+	if !strings.HasPrefix(key, g.prefix) {
+		return "", false
+	}
+	id := key[len(g.prefix):]
+	if id == "" || strings.Contains(id, "/") {
+		return "", false
+	}
+	return id, true
+}
+
+// enqueue adds an event to the queue of the dispatcher. It must be called with
+// the mutex held
+func (g *group) enqueue(e Event) {
+	g.log.Debug().
+		Stringer("type", e.Type).
+		Str("member", e.Member.Id).
+		Msg("Membership changed")
+
+	g.pending = append(g.pending, e)
+	select {
+	case g.pendingCh <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch forwards the queued events to the events channel, so that a slow
+// receiver does not hold back the view of the group
+func (g *group) dispatch() {
+	defer g.wg.Done()
+	defer close(g.eventsCh)
+
+	for {
+		g.mutex.Lock()
+		pending := g.pending
+		g.pending = nil
+		g.mutex.Unlock()
+
+		for _, e := range pending {
+			select {
+			case g.eventsCh <- e:
+			case <-g.ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-g.pendingCh:
+		case <-g.ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package membership
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"oxia/oxia"
+	"oxia/server"
+	"testing"
+	"time"
+)
+
+func newClient(t *testing.T, serviceAddress string) oxia.SyncClient {
+	t.Helper()
+	client, err := oxia.NewSyncClient(serviceAddress, oxia.WithSessionTimeout(5*time.Second))
+	assert.NoError(t, err)
+	return client
+}
+
+func nextEvent(t *testing.T, g Group) Event {
+	t.Helper()
+	select {
+	case e := <-g.Events():
+		return e
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "membership event not received")
+		return Event{}
+	}
+}
+
+func memberIds(g Group) []string {
+	ids := make([]string, 0)
+	for _, m := range g.Members() {
+		ids = append(ids, m.Id)
+	}
+	return ids
+}
+
+func TestGroup_JoinLeave(t *testing.T) {
+	config := server.NewTestConfig()
+	config.NumShards = 4
+	standaloneServer, err := server.NewStandalone(config)
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client1 := newClient(t, serviceAddress)
+	client2 := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	// A member registered before the group view is created
+	g1, err := NewGroup(client1, "/workers")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, g1.Register(ctx, "a/b", nil), ErrorInvalidMemberId)
+	assert.NoError(t, g1.Register(ctx, "worker-1", []byte("host-1")))
+	assert.ErrorIs(t, g1.Register(ctx, "worker-1", []byte("host-1")), ErrorAlreadyRegistered)
+
+	e := nextEvent(t, g1)
+	assert.Equal(t, MemberJoined, e.Type)
+	assert.Equal(t, "worker-1", e.Member.Id)
+	assert.Equal(t, "host-1", string(e.Member.Metadata))
+
+	g2, err := NewGroup(client2, "/workers")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"worker-1"}, memberIds(g2))
+	e = nextEvent(t, g2)
+	assert.Equal(t, MemberJoined, e.Type)
+	assert.Equal(t, "worker-1", e.Member.Id)
+
+	assert.NoError(t, g2.Register(ctx, "worker-2", []byte("host-2")))
+	for _, g := range []Group{g1, g2} {
+		e = nextEvent(t, g)
+		assert.Equal(t, MemberJoined, e.Type)
+		assert.Equal(t, "worker-2", e.Member.Id)
+		assert.Equal(t, "host-2", string(e.Member.Metadata))
+		assert.Equal(t, []string{"worker-1", "worker-2"}, memberIds(g))
+	}
+
+	assert.NoError(t, g2.UpdateMetadata(ctx, []byte("host-2b")))
+	e = nextEvent(t, g1)
+	assert.Equal(t, MemberUpdated, e.Type)
+	assert.Equal(t, "host-2b", string(e.Member.Metadata))
+
+	// Records outside the group are ignored
+	_, err = client1.Put(ctx, "/workers/worker-3/child", []byte("x"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, g2.Deregister(ctx))
+	assert.ErrorIs(t, g2.Deregister(ctx), ErrorNotRegistered)
+	e = nextEvent(t, g1)
+	assert.Equal(t, MemberLeft, e.Type)
+	assert.Equal(t, "worker-2", e.Member.Id)
+	assert.Equal(t, []string{"worker-1"}, memberIds(g1))
+
+	e = nextEvent(t, g2)
+	assert.Equal(t, MemberUpdated, e.Type)
+	e = nextEvent(t, g2)
+	assert.Equal(t, MemberLeft, e.Type)
+	assert.Equal(t, "worker-2", e.Member.Id)
+
+	// The member leaves when the session of its client expires
+	client3 := newClient(t, serviceAddress)
+	_, err = client3.Put(ctx, "/workers/worker-3", []byte("host-3"), oxia.Ephemeral())
+	assert.NoError(t, err)
+	for _, g := range []Group{g1, g2} {
+		assert.Equal(t, MemberJoined, nextEvent(t, g).Type)
+	}
+
+	assert.NoError(t, client3.Close())
+	for _, g := range []Group{g1, g2} {
+		e = nextEvent(t, g)
+		assert.Equal(t, MemberLeft, e.Type)
+		assert.Equal(t, "worker-3", e.Member.Id)
+		assert.Equal(t, []string{"worker-1"}, memberIds(g))
+	}
+
+	assert.NoError(t, g1.Close())
+	e = nextEvent(t, g2)
+	assert.Equal(t, MemberLeft, e.Type)
+	assert.Equal(t, "worker-1", e.Member.Id)
+	assert.Empty(t, g2.Members())
+
+	assert.NoError(t, g2.Close())
+	_, more := <-g2.Events()
+	assert.False(t, more)
+	assert.ErrorIs(t, g2.Register(ctx, "worker-2", nil), ErrorGroupClosed)
+
+	assert.NoError(t, client1.Close())
+	assert.NoError(t, client2.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
+
+func TestGroup_Reconcile(t *testing.T) {
+	standaloneServer, err := server.NewStandalone(server.NewTestConfig())
+	assert.NoError(t, err)
+	serviceAddress := fmt.Sprintf("localhost:%d", standaloneServer.RpcPort())
+
+	client := newClient(t, serviceAddress)
+	ctx := context.Background()
+
+	gi, err := NewGroup(client, "/workers")
+	assert.NoError(t, err)
+	g := gi.(*group)
+
+	_, err = client.Put(ctx, "/workers/worker-1", []byte("host-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, MemberJoined, nextEvent(t, g).Type)
+
+	// Simulate the changes missed during a gap in the notifications
+	g.mutex.Lock()
+	g.members["worker-0"] = Member{Id: "worker-0"}
+	g.mutex.Unlock()
+
+	_, err = client.Put(ctx, "/workers/worker-2", []byte("host-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, MemberJoined, nextEvent(t, g).Type)
+
+	assert.NoError(t, g.reconcile())
+	e := nextEvent(t, g)
+	assert.Equal(t, MemberLeft, e.Type)
+	assert.Equal(t, "worker-0", e.Member.Id)
+	assert.Equal(t, []string{"worker-1", "worker-2"}, memberIds(g))
+
+	// The reconciliation does not emit events for the members that did not change
+	select {
+	case e := <-g.Events():
+		assert.Fail(t, "unexpected event", e)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	assert.NoError(t, g.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, standaloneServer.Close())
+}
@@ -54,22 +54,37 @@ type sessions struct {
 }
 
 func (s *sessions) executeWithSessionId(shardId int64, callback func(int64, error)) {
+	s.executeWithSession(shardId, func(session *clientSession, err error) {
+		if err != nil {
+			callback(-1, err)
+			return
+		}
+		callback(session.sessionId, nil)
+	})
+}
+
+// executeWithSession passes the current session of the shard to the callback,
+// once it is created
+func (s *sessions) executeWithSession(shardId int64, callback func(*clientSession, error)) {
 	s.Lock()
-	defer s.Unlock()
 	session, found := s.sessionsByShard[shardId]
 	if !found {
 		session = s.startSession(shardId)
 		s.sessionsByShard[shardId] = session
 	}
-	session.executeWithId(callback)
+	s.Unlock()
+	session.executeWithSession(callback)
 }
 
 func (s *sessions) startSession(shardId int64) *clientSession {
+	ctx, cancel := context.WithCancel(s.ctx)
 	cs := &clientSession{
 		shardId:  shardId,
 		sessions: s,
-		ctx:      s.ctx,
-		started:  make(chan error),
+		ctx:      ctx,
+		cancel:   cancel,
+		started:  make(chan struct{}),
+		done:     make(chan struct{}),
 		log: log.With().
 			Str("component", "session").
 			Int64("shard", shardId).Logger(),
@@ -84,33 +99,25 @@ func (s *sessions) startSession(shardId int64) *clientSession {
 
 type clientSession struct {
 	sync.Mutex
-	started   chan error
-	shardId   int64
-	sessionId int64
-	log       zerolog.Logger
-	sessions  *sessions
-	ctx       context.Context
+	started    chan struct{}
+	startErr   error
+	done       chan struct{}
+	doneOnce   sync.Once
+	expiration *time.Timer
+	shardId    int64
+	sessionId  int64
+	log        zerolog.Logger
+	sessions   *sessions
+	ctx        context.Context
+	cancel     context.CancelFunc
 }
 
-func (cs *clientSession) executeWithId(callback func(int64, error)) {
-	select {
-	case err := <-cs.started:
-		if err != nil {
-			callback(-1, err)
-			cs.sessions.Lock()
-			defer cs.sessions.Unlock()
-			cs.Lock()
-			defer cs.Unlock()
-			delete(cs.sessions.sessionsByShard, cs.shardId)
-		} else {
-			cs.Lock()
-			callback(cs.sessionId, nil)
-			cs.Unlock()
-		}
-	case <-cs.ctx.Done():
-		if cs.ctx.Err() != nil && cs.ctx.Err() != context.Canceled {
-			callback(-1, cs.ctx.Err())
-		}
+func (cs *clientSession) executeWithSession(callback func(*clientSession, error)) {
+	<-cs.started
+	if cs.startErr != nil {
+		callback(nil, cs.startErr)
+	} else {
+		callback(cs, nil)
 	}
 }
 
@@ -125,11 +132,10 @@ func (cs *clientSession) createSessionWithRetries() {
 			}
 
 		})
-	if err != nil && err != context.Canceled {
-		cs.Lock()
-		cs.started <- err
+	if err != nil {
+		cs.startErr = err
 		close(cs.started)
-		cs.Unlock()
+		cs.lost()
 	}
 }
 
@@ -138,12 +144,14 @@ func (cs *clientSession) createSession() error {
 	if err != nil {
 		return err
 	}
+	timeout := cs.sessions.clientOpts.sessionTimeout
+	start := time.Now()
 	ctx, cancel := context.WithTimeout(cs.ctx, cs.sessions.clientOpts.requestTimeout)
 	defer cancel()
 	createSessionResponse, err := rpc.CreateSession(ctx, &proto.CreateSessionRequest{
 		ShardId:          cs.shardId,
 		ClientIdentity:   cs.sessions.clientIdentity,
-		SessionTimeoutMs: uint32(cs.sessions.clientOpts.sessionTimeout.Milliseconds()),
+		SessionTimeoutMs: uint32(timeout.Milliseconds()),
 	})
 	if err != nil {
 		return err
@@ -153,6 +161,9 @@ func (cs *clientSession) createSession() error {
 	defer cs.Unlock()
 	cs.sessionId = sessionId
 	cs.log = cs.log.With().Int64("session-id", sessionId).Logger()
+	// The server expires the session if it does not receive any keep-alive
+	// within the timeout, counted at the latest from the creation request
+	cs.expiration = time.AfterFunc(timeout-time.Since(start), cs.lost)
 	close(cs.started)
 	cs.log.Debug().Msg("Successfully created session")
 
@@ -161,18 +172,13 @@ func (cs *clientSession) createSession() error {
 		"shard":   fmt.Sprintf("%d", cs.shardId),
 		"session": fmt.Sprintf("%x016", cs.sessionId),
 	}, func() {
+		defer cs.lost()
 
-		backOff := common.NewBackOff(cs.sessions.ctx)
+		backOff := common.NewBackOff(cs.ctx)
 		err := backoff.RetryNotify(func() error {
 			err := cs.keepAlive()
 			if status.Code(err) == common.CodeInvalidSession {
 				cs.log.Error().Err(err).Msg("Session is no longer valid")
-
-				cs.sessions.Lock()
-				defer cs.sessions.Unlock()
-				cs.Lock()
-				defer cs.Unlock()
-				delete(cs.sessions.sessionsByShard, cs.shardId)
 				return backoff.Permanent(err)
 			}
 			return err
@@ -181,7 +187,7 @@ func (cs *clientSession) createSession() error {
 				Dur("retry-after", duration).
 				Msg("Failed to send session heartbeat, retrying later")
 		})
-		if !errors.Is(err, context.Canceled) {
+		if err != nil && !errors.Is(err, context.Canceled) {
 			cs.log.Error().Err(err).Msg("Failed to keep alive session.")
 		}
 	})
@@ -189,25 +195,55 @@ func (cs *clientSession) createSession() error {
 	return nil
 }
 
+// lost is called once the session can no longer be kept alive, or the client
+// is closed. The next ephemeral records of the shard are written with a new
+// session.
+func (cs *clientSession) lost() {
+	cs.doneOnce.Do(func() {
+		cs.sessions.Lock()
+		if cs.sessions.sessionsByShard[cs.shardId] == cs {
+			delete(cs.sessions.sessionsByShard, cs.shardId)
+		}
+		cs.sessions.Unlock()
+
+		cs.Lock()
+		if cs.expiration != nil {
+			cs.expiration.Stop()
+		}
+		if cs.sessions.ctx.Err() == nil {
+			cs.log.Warn().Msg("Session was lost")
+		}
+		cs.Unlock()
+
+		close(cs.done)
+		// Stop the keep-alives and close the session, in case it is not
+		// expired yet on the server
+		cs.cancel()
+	})
+}
+
 func (cs *clientSession) getRpc() (proto.OxiaClientClient, error) {
 	leader := cs.sessions.shardManager.Leader(cs.shardId)
 	return cs.sessions.pool.GetClientRpc(leader)
 }
 
 func (cs *clientSession) keepAlive() error {
-	cs.sessions.Lock()
 	cs.Lock()
 	timeout := cs.sessions.clientOpts.sessionTimeout
-	ctx := cs.sessions.ctx
+	requestTimeout := cs.sessions.clientOpts.requestTimeout
+	ctx := cs.ctx
 	shardId := cs.shardId
 	sessionId := cs.sessionId
 	cs.Unlock()
-	cs.sessions.Unlock()
 
 	tickTime := timeout / 10
 	if tickTime < 2*time.Second {
 		tickTime = 2 * time.Second
 	}
+	// Leave room for a few attempts before the session expires
+	if tickTime > timeout/3 {
+		tickTime = timeout / 3
+	}
 
 	ticker := time.NewTicker(tickTime)
 	defer ticker.Stop()
@@ -220,12 +256,18 @@ func (cs *clientSession) keepAlive() error {
 	for {
 		select {
 		case <-ticker.C:
-			_, err = rpc.KeepAlive(ctx, &proto.SessionHeartbeat{ShardId: shardId, SessionId: sessionId})
+			sent := time.Now()
+			keepAliveCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+			_, err = rpc.KeepAlive(keepAliveCtx, &proto.SessionHeartbeat{ShardId: shardId, SessionId: sessionId})
+			cancel()
 			if err != nil {
 				return err
 			}
+			cs.Lock()
+			cs.expiration.Reset(timeout - time.Since(sent))
+			cs.Unlock()
 		case <-ctx.Done():
-			ctx, cancel := context.WithTimeout(context.Background(), cs.sessions.clientOpts.requestTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 			rpc, err = cs.getRpc()
 			if err != nil {
 				cancel()
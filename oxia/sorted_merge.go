@@ -0,0 +1,77 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import "context"
+
+// mergeSorted merges the items coming from multiple channels, each one of them
+// already sorted, into the output channel, preserving the order.
+// If any of the items is an error, it is forwarded right away and the merge stops.
+// The output channel is closed once all the inputs are exhausted.
+func mergeSorted[T any](ctx context.Context, inputs []<-chan T, out chan<- T, less func(a, b T) bool, isError func(T) bool) {
+	defer close(out)
+
+	heads := make([]T, len(inputs))
+	valid := make([]bool, len(inputs))
+
+	next := func(i int) bool {
+		select {
+		case item, more := <-inputs[i]:
+			heads[i], valid[i] = item, more
+			if more && isError(item) {
+				sendOrDone(ctx, out, item)
+				return false
+			}
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for i := range inputs {
+		if !next(i) {
+			return
+		}
+	}
+
+	for {
+		first := -1
+		for i := range inputs {
+			if valid[i] && (first < 0 || less(heads[i], heads[first])) {
+				first = i
+			}
+		}
+
+		if first < 0 {
+			// All the inputs are exhausted
+			return
+		}
+
+		if !sendOrDone(ctx, out, heads[first]) || !next(first) {
+			return
+		}
+	}
+}
+
+// sendOrDone sends the item on the channel, unless the context is done before.
+// Returns false if the item was not sent.
+func sendOrDone[T any](ctx context.Context, ch chan<- T, item T) bool {
+	select {
+	case ch <- item:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
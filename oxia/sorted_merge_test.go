@@ -0,0 +1,73 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func sortedChannel(items ...int) <-chan int {
+	ch := make(chan int, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+func mergeSortedInts(ctx context.Context, inputs ...<-chan int) []int {
+	out := make(chan int)
+	go mergeSorted(ctx, inputs, out, func(a, b int) bool {
+		return a < b
+	}, func(i int) bool {
+		return i < 0
+	})
+
+	res := make([]int, 0)
+	for i := range out {
+		res = append(res, i)
+	}
+	return res
+}
+
+func TestMergeSorted(t *testing.T) {
+	assert.Equal(t, []int{}, mergeSortedInts(context.Background()))
+	assert.Equal(t, []int{}, mergeSortedInts(context.Background(), sortedChannel(), sortedChannel()))
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, mergeSortedInts(context.Background(),
+		sortedChannel(1, 4, 7),
+		sortedChannel(),
+		sortedChannel(2, 3),
+		sortedChannel(5, 6),
+	))
+}
+
+func TestMergeSorted_Error(t *testing.T) {
+	// The error is forwarded and the merge stops
+	assert.Equal(t, []int{1, 2, -1}, mergeSortedInts(context.Background(),
+		sortedChannel(1, 4, 7),
+		sortedChannel(2, -1, 3),
+	))
+}
+
+func TestMergeSorted_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The input is never closed
+	assert.Equal(t, []int{}, mergeSortedInts(ctx, make(chan int)))
+}
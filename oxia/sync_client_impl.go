@@ -16,6 +16,8 @@ package oxia
 
 import (
 	"context"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.uber.org/multierr"
 	"sync"
 )
@@ -61,8 +63,13 @@ func (c *syncClientImpl) getCacheManager() (*cacheManager, error) {
 		return c.cacheManager, nil
 	}
 
+	var meterProvider metric.MeterProvider = noop.NewMeterProvider()
+	if asyncClient, ok := c.asyncClient.(*clientImpl); ok {
+		meterProvider = asyncClient.options.meterProvider
+	}
+
 	var err error
-	c.cacheManager, err = newCacheManager(c)
+	c.cacheManager, err = newCacheManager(c, meterProvider)
 	return c.cacheManager, err
 }
 
@@ -86,6 +93,24 @@ func (c *syncClientImpl) Put(ctx context.Context, key string, value []byte, opti
 	}
 }
 
+func (c *syncClientImpl) Increment(ctx context.Context, key string, delta int64, options ...PutOption) (int64, Version, error) {
+	select {
+	case r := <-c.asyncClient.Increment(key, delta, options...):
+		return r.Value, r.Version, r.Err
+	case <-ctx.Done():
+		return 0, Version{}, ctx.Err()
+	}
+}
+
+func (c *syncClientImpl) Append(ctx context.Context, key string, value []byte, options ...PutOption) ([]byte, Version, error) {
+	select {
+	case r := <-c.asyncClient.Append(key, value, options...):
+		return r.Value, r.Version, r.Err
+	case <-ctx.Done():
+		return nil, Version{}, ctx.Err()
+	}
+}
+
 func (c *syncClientImpl) Delete(ctx context.Context, key string, options ...DeleteOption) error {
 	select {
 	case err := <-c.asyncClient.Delete(key, options...):
@@ -104,19 +129,76 @@ func (c *syncClientImpl) DeleteRange(ctx context.Context, minKeyInclusive string
 	}
 }
 
-func (c *syncClientImpl) Get(ctx context.Context, key string) ([]byte, Version, error) {
+func (c *syncClientImpl) DeleteRecursive(ctx context.Context, path string) error {
+	select {
+	case err := <-c.asyncClient.DeleteRecursive(path):
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *syncClientImpl) Get(ctx context.Context, key string, options ...GetOption) ([]byte, Version, error) {
 	select {
-	case r := <-c.asyncClient.Get(key):
+	case r := <-c.asyncClient.Get(key, options...):
 		return r.Value, r.Version, r.Err
 	case <-ctx.Done():
 		return nil, Version{}, ctx.Err()
 	}
 }
 
-func (c *syncClientImpl) List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan ListResult {
-	return c.asyncClient.List(ctx, minKeyInclusive, maxKeyExclusive)
+func (c *syncClientImpl) Stat(ctx context.Context, key string, options ...GetOption) (Version, error) {
+	select {
+	case r := <-c.asyncClient.Stat(key, options...):
+		return r.Version, r.Err
+	case <-ctx.Done():
+		return Version{}, ctx.Err()
+	}
+}
+
+func (c *syncClientImpl) Exists(ctx context.Context, key string, options ...GetOption) (bool, error) {
+	select {
+	case r := <-c.asyncClient.Exists(key, options...):
+		return r.Exists, r.Err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (c *syncClientImpl) List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string, options ...ListOption) <-chan ListResult {
+	return c.asyncClient.List(ctx, minKeyInclusive, maxKeyExclusive, options...)
+}
+
+func (c *syncClientImpl) ListChildren(ctx context.Context, path string, options ...ListOption) <-chan ListResult {
+	return c.asyncClient.ListChildren(ctx, path, options...)
+}
+
+func (c *syncClientImpl) RangeScan(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan GetResult {
+	return c.asyncClient.RangeScan(ctx, minKeyInclusive, maxKeyExclusive)
+}
+
+func (c *syncClientImpl) CountRange(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) (int64, int64, error) {
+	select {
+	case r := <-c.asyncClient.CountRange(ctx, minKeyInclusive, maxKeyExclusive):
+		return r.Count, r.TotalValueSize, r.Err
+	case <-ctx.Done():
+		return 0, 0, ctx.Err()
+	}
+}
+
+func (c *syncClientImpl) GetNotifications(options ...NotificationsOption) (Notifications, error) {
+	return c.asyncClient.GetNotifications(options...)
+}
+
+func (c *syncClientImpl) SessionDone(ctx context.Context, partitionKey string) (<-chan struct{}, error) {
+	select {
+	case r := <-c.asyncClient.SessionDone(partitionKey):
+		return r.Done, r.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
-func (c *syncClientImpl) GetNotifications() (Notifications, error) {
-	return c.asyncClient.GetNotifications()
+func (c *syncClientImpl) Txn() SyncTxn {
+	return &syncTxn{c.asyncClient.Txn()}
 }
@@ -29,6 +29,14 @@ func (c *neverCompleteAsyncClient) Put(key string, value []byte, options ...PutO
 	return make(chan PutResult)
 }
 
+func (c *neverCompleteAsyncClient) Increment(key string, delta int64, options ...PutOption) <-chan IncrementResult {
+	return make(chan IncrementResult)
+}
+
+func (c *neverCompleteAsyncClient) Append(key string, value []byte, options ...PutOption) <-chan AppendResult {
+	return make(chan AppendResult)
+}
+
 func (c *neverCompleteAsyncClient) Delete(key string, options ...DeleteOption) <-chan error {
 	return make(chan error)
 }
@@ -37,15 +45,47 @@ func (c *neverCompleteAsyncClient) DeleteRange(minKeyInclusive string, maxKeyExc
 	return make(chan error)
 }
 
-func (c *neverCompleteAsyncClient) Get(key string) <-chan GetResult {
+func (c *neverCompleteAsyncClient) Get(key string, options ...GetOption) <-chan GetResult {
+	return make(chan GetResult)
+}
+
+func (c *neverCompleteAsyncClient) List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string, options ...ListOption) <-chan ListResult {
+	panic("not implemented")
+}
+
+func (c *neverCompleteAsyncClient) Stat(key string, options ...GetOption) <-chan GetResult {
 	return make(chan GetResult)
 }
 
-func (c *neverCompleteAsyncClient) List(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan ListResult {
+func (c *neverCompleteAsyncClient) Exists(key string, options ...GetOption) <-chan ExistsResult {
+	return make(chan ExistsResult)
+}
+
+func (c *neverCompleteAsyncClient) DeleteRecursive(path string) <-chan error {
+	return make(chan error)
+}
+
+func (c *neverCompleteAsyncClient) ListChildren(ctx context.Context, path string, options ...ListOption) <-chan ListResult {
+	return make(chan ListResult)
+}
+
+func (c *neverCompleteAsyncClient) RangeScan(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan GetResult {
+	panic("not implemented")
+}
+
+func (c *neverCompleteAsyncClient) CountRange(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan CountRangeResult {
+	return make(chan CountRangeResult)
+}
+
+func (c *neverCompleteAsyncClient) GetNotifications(options ...NotificationsOption) (Notifications, error) {
 	panic("not implemented")
 }
 
-func (c *neverCompleteAsyncClient) GetNotifications() (Notifications, error) {
+func (c *neverCompleteAsyncClient) SessionDone(partitionKey string) <-chan SessionDoneResult {
+	return make(chan SessionDoneResult)
+}
+
+func (c *neverCompleteAsyncClient) Txn() AsyncTxn {
 	panic("not implemented")
 }
 
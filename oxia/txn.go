@@ -0,0 +1,202 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"context"
+	"oxia/oxia/internal/model"
+	"oxia/proto"
+)
+
+type asyncTxn struct {
+	client  *clientImpl
+	shardId *int64
+	puts    []model.PutCall
+	deletes []model.DeleteCall
+	// The destinations of the previous records, for each operation
+	putPrevious    []*PreviousRecord
+	deletePrevious []*PreviousRecord
+	ephemeral      bool
+	err            error
+}
+
+func newAsyncTxn(client *clientImpl) *asyncTxn {
+	return &asyncTxn{
+		client:  client,
+		puts:    make([]model.PutCall, 0),
+		deletes: make([]model.DeleteCall, 0),
+	}
+}
+
+func (t *asyncTxn) Put(key string, value []byte, options ...PutOption) AsyncTxn {
+	opts := newPutOptions(options)
+	t.checkShard(shardingKey(key, opts.partitionKey))
+
+	putCall := model.PutCall{
+		Key:               key,
+		Value:             value,
+		ExpectedVersionId: opts.expectedVersion,
+		SequenceKeyDeltas: opts.sequenceKeyDeltas,
+		TtlMs:             opts.ttlMs(),
+		ReturnPrevious:    opts.previous != nil,
+	}
+	if err := opts.validate(); err != nil {
+		t.err = err
+	}
+	if opts.ephemeral {
+		putCall.ClientIdentity = &t.client.options.identity
+		t.ephemeral = true
+	}
+	t.puts = append(t.puts, putCall)
+	t.putPrevious = append(t.putPrevious, opts.previous)
+	return t
+}
+
+func (t *asyncTxn) Delete(key string, options ...DeleteOption) AsyncTxn {
+	opts := newDeleteOptions(options)
+	t.checkShard(shardingKey(key, opts.partitionKey))
+
+	t.deletes = append(t.deletes, model.DeleteCall{
+		Key:               key,
+		ExpectedVersionId: opts.expectedVersion,
+		ReturnPrevious:    opts.previous != nil,
+	})
+	t.deletePrevious = append(t.deletePrevious, opts.previous)
+	return t
+}
+
+func (t *asyncTxn) checkShard(key string) {
+	shardId := t.client.shardManager.Get(key)
+	if t.shardId == nil {
+		t.shardId = &shardId
+	} else if *t.shardId != shardId {
+		t.err = ErrorTransactionSpansShards
+	}
+}
+
+func (t *asyncTxn) Commit() <-chan TxnResult {
+	ch := make(chan TxnResult, 1)
+	if t.err != nil || t.shardId == nil {
+		// Either the transaction is invalid or there is nothing to commit
+		ch <- TxnResult{Err: t.err}
+		close(ch)
+		return ch
+	}
+
+	if !t.ephemeral {
+		go t.execute(ch)
+		return ch
+	}
+
+	t.client.sessions.executeWithSessionId(*t.shardId, func(sessionId int64, err error) {
+		if err != nil {
+			ch <- TxnResult{Err: err}
+			close(ch)
+			return
+		}
+
+		for i := range t.puts {
+			if t.puts[i].ClientIdentity != nil {
+				t.puts[i].SessionId = &sessionId
+			}
+		}
+		go t.execute(ch)
+	})
+	return ch
+}
+
+func (t *asyncTxn) execute(ch chan<- TxnResult) {
+	defer close(ch)
+
+	var rpcErr error
+	putResponses := make([]*proto.PutResponse, len(t.puts))
+	deleteResponses := make([]*proto.DeleteResponse, len(t.deletes))
+
+	b := t.client.batcherFactory.NewTransaction(t.shardId, t.client.options.maxBatchSize)
+	for i, putCall := range t.puts {
+		i := i
+		putCall.Callback = func(response *proto.PutResponse, err error) {
+			putResponses[i] = response
+			rpcErr = err
+		}
+		if !b.CanAdd(putCall) {
+			ch <- TxnResult{Err: ErrorRequestTooLarge}
+			return
+		}
+		b.Add(putCall)
+	}
+	for i, deleteCall := range t.deletes {
+		i := i
+		deleteCall.Callback = func(response *proto.DeleteResponse, err error) {
+			deleteResponses[i] = response
+			rpcErr = err
+		}
+		if !b.CanAdd(deleteCall) {
+			ch <- TxnResult{Err: ErrorRequestTooLarge}
+			return
+		}
+		b.Add(deleteCall)
+	}
+
+	// The callbacks are invoked synchronously when the batch completes
+	b.Complete()
+
+	if rpcErr != nil {
+		ch <- TxnResult{Err: rpcErr}
+		return
+	}
+
+	result := toTxnResult(t.putKeys(), putResponses, deleteResponses)
+	if result.Err == nil {
+		for i, r := range putResponses {
+			setPrevious(t.putPrevious[i], t.puts[i].Key, r.Status, r.PreviousValue, r.PreviousVersion)
+		}
+		for i, r := range deleteResponses {
+			setPrevious(t.deletePrevious[i], t.deletes[i].Key, r.Status, r.PreviousValue, r.PreviousVersion)
+		}
+	}
+	ch <- result
+}
+
+func (t *asyncTxn) putKeys() []string {
+	keys := make([]string, len(t.puts))
+	for i, putCall := range t.puts {
+		keys[i] = putCall.Key
+	}
+	return keys
+}
+
+type syncTxn struct {
+	txn AsyncTxn
+}
+
+func (t *syncTxn) Put(key string, value []byte, options ...PutOption) SyncTxn {
+	t.txn.Put(key, value, options...)
+	return t
+}
+
+func (t *syncTxn) Delete(key string, options ...DeleteOption) SyncTxn {
+	t.txn.Delete(key, options...)
+	return t
+}
+
+func (t *syncTxn) Commit(ctx context.Context) ([]Version, error) {
+	select {
+	case r := <-t.txn.Commit():
+		return r.Versions, r.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
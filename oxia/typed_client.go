@@ -0,0 +1,252 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"sync"
+)
+
+// TypedClient is a view of a [SyncClient] that stores values of a specific
+// type, which are serialized with a [Codec].
+//
+// The options of the operations are the same as the ones of the [SyncClient].
+type TypedClient[T any] interface {
+	// Put associates a value with a key.
+	// Returns a [Version] object that contains information about the newly updated record
+	Put(ctx context.Context, key string, value T, options ...PutOption) (Version, error)
+
+	// Get returns the value associated with the specified key, and its version.
+	// Returns ErrorKeyNotFound if the record does not exist
+	Get(ctx context.Context, key string, options ...GetOption) (T, Version, error)
+
+	// Delete removes the key and its associated value from the data store.
+	Delete(ctx context.Context, key string, options ...DeleteOption) error
+
+	// RangeScan returns all the records within the specified range, in the order
+	// of their keys. If an error occurs, a result with the error is returned and
+	// the channel gets closed.
+	RangeScan(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan TypedGetResult[T]
+
+	// GetNotifications creates a new subscription to the changes of the records,
+	// which carries their decoded values. It supports the same options as
+	// [SyncClient.GetNotifications], and [IncludeValues] is always set.
+	GetNotifications(options ...NotificationsOption) (TypedNotifications[T], error)
+}
+
+// TypedGetResult structure is wrapping a decoded value, its version information
+// and an eventual error as results for a `RangeScan` operation in the [TypedClient]
+type TypedGetResult[T any] struct {
+	// Value is the decoded value of the record
+	Value T
+
+	// The version information
+	Version Version
+
+	// The error if the operation failed, or if the value could not be decoded
+	Err error
+}
+
+// TypedNotifications represents a subscription to the changes of the records of
+// a [TypedClient].
+type TypedNotifications[T any] interface {
+	io.Closer
+
+	// Ch exposes the channel where all the notification events are published
+	Ch() <-chan *TypedNotification[T]
+
+	// Err returns the error that interrupted the notifications, once the channel
+	// is closed. See [Notifications.Err]
+	Err() error
+}
+
+// TypedNotification is a [Notification] with the decoded value of the record.
+type TypedNotification[T any] struct {
+	// The type of the modification
+	Type NotificationType
+
+	// The Key of the record to which the notification is referring
+	Key string
+
+	// The current VersionId of the record, or -1 for a KeyDeleted event
+	VersionId int64
+
+	// Value is the decoded new value of the record, when HasValue is true
+	Value T
+
+	// HasValue is false for a KeyDeleted event, or when the notification did
+	// not carry the value, as described in [Notification.Value]
+	HasValue bool
+
+	// The VersionId of the record before the change, when the subscription was
+	// created with [IncludePreviousVersion]. It is -1 for a KeyCreated event
+	PreviousVersionId int64
+
+	// The shard of the record
+	Shard int64
+
+	// The offset of the change in the shard. See [Notification.Offset]
+	Offset int64
+
+	// Whether this is the last notification of the write batch. See
+	// [Notification.LastInBatch]
+	LastInBatch bool
+
+	// Err is set if the value could not be decoded
+	Err error
+}
+
+// NewTypedClient creates a typed view of the client, which uses the codec to
+// serialize the values. The client is still owned by the caller, that must
+// close it once it's not used anymore.
+func NewTypedClient[T any](client SyncClient, codec Codec[T]) TypedClient[T] {
+	return &typedClient[T]{
+		client: client,
+		codec:  codec,
+	}
+}
+
+type typedClient[T any] struct {
+	client SyncClient
+	codec  Codec[T]
+}
+
+func (c *typedClient[T]) Put(ctx context.Context, key string, value T, options ...PutOption) (Version, error) {
+	data, err := c.codec.Encode(value)
+	if err != nil {
+		return Version{}, errors.Wrapf(err, "failed to encode the value of %s", key)
+	}
+	return c.client.Put(ctx, key, data, options...)
+}
+
+func (c *typedClient[T]) Get(ctx context.Context, key string, options ...GetOption) (T, Version, error) {
+	var value T
+	data, version, err := c.client.Get(ctx, key, options...)
+	if err != nil {
+		return value, version, err
+	}
+
+	if value, err = c.decode(version.Key, data); err != nil {
+		return value, Version{}, err
+	}
+	return value, version, nil
+}
+
+func (c *typedClient[T]) Delete(ctx context.Context, key string, options ...DeleteOption) error {
+	return c.client.Delete(ctx, key, options...)
+}
+
+func (c *typedClient[T]) RangeScan(ctx context.Context, minKeyInclusive string, maxKeyExclusive string) <-chan TypedGetResult[T] {
+	// Stop the scan as soon as the results are not consumed anymore
+	ctx, cancel := context.WithCancel(ctx)
+
+	results := c.client.RangeScan(ctx, minKeyInclusive, maxKeyExclusive)
+	ch := make(chan TypedGetResult[T])
+	go func() {
+		defer cancel()
+		defer close(ch)
+
+		for r := range results {
+			result := TypedGetResult[T]{Version: r.Version, Err: r.Err}
+			if r.Err == nil {
+				result.Value, result.Err = c.decode(r.Version.Key, r.Value)
+			}
+			if !sendOrDone(ctx, ch, result) || result.Err != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func (c *typedClient[T]) GetNotifications(options ...NotificationsOption) (TypedNotifications[T], error) {
+	options = append(options[:len(options):len(options)], IncludeValues())
+	notifications, err := c.client.GetNotifications(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	tn := &typedNotifications[T]{
+		notifications: notifications,
+		client:        c,
+		ch:            make(chan *TypedNotification[T]),
+		closed:        make(chan struct{}),
+	}
+	go tn.run()
+	return tn, nil
+}
+
+func (c *typedClient[T]) decode(key string, data []byte) (T, error) {
+	value, err := c.codec.Decode(data)
+	if err != nil {
+		return value, errors.Wrapf(err, "failed to decode the value of %s", key)
+	}
+	return value, nil
+}
+
+type typedNotifications[T any] struct {
+	notifications Notifications
+	client        *typedClient[T]
+	ch            chan *TypedNotification[T]
+	closed        chan struct{}
+	closeOnce     sync.Once
+}
+
+func (tn *typedNotifications[T]) Ch() <-chan *TypedNotification[T] {
+	return tn.ch
+}
+
+func (tn *typedNotifications[T]) Err() error {
+	return tn.notifications.Err()
+}
+
+func (tn *typedNotifications[T]) Close() error {
+	tn.closeOnce.Do(func() {
+		close(tn.closed)
+	})
+	return tn.notifications.Close()
+}
+
+func (tn *typedNotifications[T]) run() {
+	defer close(tn.ch)
+
+	for n := range tn.notifications.Ch() {
+		if n == nil {
+			continue
+		}
+
+		typed := &TypedNotification[T]{
+			Type:              n.Type,
+			Key:               n.Key,
+			VersionId:         n.VersionId,
+			PreviousVersionId: n.PreviousVersionId,
+			Shard:             n.Shard,
+			Offset:            n.Offset,
+			LastInBatch:       n.LastInBatch,
+		}
+		if n.Type != KeyDeleted && n.Value != nil {
+			typed.Value, typed.Err = tn.client.decode(n.Key, n.Value)
+			typed.HasValue = typed.Err == nil
+		}
+
+		select {
+		case tn.ch <- typed:
+		case <-tn.closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oxia
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTypedClient(t *testing.T) {
+	client, err := NewSyncClient(serviceAddress)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	typed := NewTypedClient[testStruct](client, JSONCodec[testStruct]())
+	prefix := newKey()
+
+	notifications, err := typed.GetNotifications(FilterKeyPrefix(prefix))
+	assert.NoError(t, err)
+
+	v1, err := typed.Put(ctx, prefix+"/a", testStruct{"a", 1})
+	assert.NoError(t, err)
+	_, err = typed.Put(ctx, prefix+"/b", testStruct{"b", 2}, ExpectedRecordNotExists())
+	assert.NoError(t, err)
+
+	value, version, err := typed.Get(ctx, prefix+"/a")
+	assert.NoError(t, err)
+	assert.Equal(t, testStruct{"a", 1}, value)
+	assert.Equal(t, v1, version)
+
+	_, _, err = typed.Get(ctx, prefix+"/c")
+	assert.ErrorIs(t, err, ErrorKeyNotFound)
+
+	var values []testStruct
+	for r := range typed.RangeScan(ctx, prefix+"/", prefix+"//") {
+		assert.NoError(t, r.Err)
+		values = append(values, r.Value)
+	}
+	assert.Equal(t, []testStruct{{"a", 1}, {"b", 2}}, values)
+
+	// A value that cannot be decoded
+	_, err = client.Put(ctx, prefix+"/c", []byte("not-json"))
+	assert.NoError(t, err)
+	_, _, err = typed.Get(ctx, prefix+"/c")
+	assert.Error(t, err)
+
+	assert.NoError(t, typed.Delete(ctx, prefix+"/a"))
+
+	for _, expected := range []struct {
+		notificationType NotificationType
+		key              string
+		value            testStruct
+		hasValue         bool
+		hasErr           bool
+	}{
+		{KeyCreated, prefix + "/a", testStruct{"a", 1}, true, false},
+		{KeyCreated, prefix + "/b", testStruct{"b", 2}, true, false},
+		{KeyCreated, prefix + "/c", testStruct{}, false, true},
+		{KeyDeleted, prefix + "/a", testStruct{}, false, false},
+	} {
+		select {
+		case n := <-notifications.Ch():
+			assert.Equal(t, expected.notificationType, n.Type)
+			assert.Equal(t, expected.key, n.Key)
+			assert.Equal(t, expected.value, n.Value)
+			assert.Equal(t, expected.hasValue, n.HasValue)
+			assert.Equal(t, expected.hasErr, n.Err != nil)
+		case <-time.After(10 * time.Second):
+			assert.FailNow(t, "notification not received")
+		}
+	}
+
+	assert.NoError(t, notifications.Close())
+	_, more := <-notifications.Ch()
+	assert.False(t, more)
+	assert.NoError(t, notifications.Err())
+
+	assert.NoError(t, client.Close())
+}
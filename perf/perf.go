@@ -22,6 +22,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"golang.org/x/time/rate"
 	"math/rand"
+	"oxia/common/security"
 	"oxia/oxia"
 	"sync/atomic"
 	"time"
@@ -38,6 +39,9 @@ type Config struct {
 	BatchLinger         time.Duration
 	MaxRequestsPerBatch int
 	RequestTimeout      time.Duration
+
+	TLS       security.TLSOptions
+	AuthToken string
 }
 
 type Perf interface {
@@ -66,12 +70,23 @@ func (p *perf) Run(ctx context.Context) {
 		p.keys[i] = fmt.Sprintf("key-%d", i)
 	}
 
-	client, err := oxia.NewAsyncClient(p.config.ServiceAddr,
+	tlsConf, err := p.config.TLS.MakeClientTLSConf()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load the TLS configuration")
+	}
+
+	options := []oxia.ClientOption{
 		oxia.WithNamespace(p.config.Namespace),
 		oxia.WithBatchLinger(p.config.BatchLinger),
 		oxia.WithMaxRequestsPerBatch(p.config.MaxRequestsPerBatch),
 		oxia.WithRequestTimeout(p.config.RequestTimeout),
-	)
+		oxia.WithTLS(tlsConf),
+	}
+	if p.config.AuthToken != "" {
+		options = append(options, oxia.WithAuthentication(oxia.NewTokenAuthentication(p.config.AuthToken)))
+	}
+
+	client, err := oxia.NewAsyncClient(p.config.ServiceAddr, options...)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create Oxia client")
 	}
@@ -49,6 +49,19 @@ type OxiaClientClient interface {
 	//
 	// Clients should send an equivalent request to all respective shards.
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (OxiaClient_ListClient, error)
+	// *
+	// Requests all the records between a range of keys, including their values
+	// and versions.
+	//
+	// Clients should send an equivalent request to all respective shards.
+	RangeScan(ctx context.Context, in *RangeScanRequest, opts ...grpc.CallOption) (OxiaClient_RangeScanClient, error)
+	// *
+	// Counts the records between a range of keys, and the total size of
+	// their values.
+	//
+	// Clients should send an equivalent request to all respective shards,
+	// and aggregate the responses.
+	CountRange(ctx context.Context, in *CountRangeRequest, opts ...grpc.CallOption) (*CountRangeResponse, error)
 	GetNotifications(ctx context.Context, in *NotificationsRequest, opts ...grpc.CallOption) (OxiaClient_GetNotificationsClient, error)
 	// Creates a new client session. Sessions are kept alive by regularly sending
 	// heartbeats via the KeepAlive rpc.
@@ -172,8 +185,49 @@ func (x *oxiaClientListClient) Recv() (*ListResponse, error) {
 	return m, nil
 }
 
+func (c *oxiaClientClient) RangeScan(ctx context.Context, in *RangeScanRequest, opts ...grpc.CallOption) (OxiaClient_RangeScanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &OxiaClient_ServiceDesc.Streams[3], "/io.streamnative.oxia.proto.OxiaClient/RangeScan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oxiaClientRangeScanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OxiaClient_RangeScanClient interface {
+	Recv() (*RangeScanResponse, error)
+	grpc.ClientStream
+}
+
+type oxiaClientRangeScanClient struct {
+	grpc.ClientStream
+}
+
+func (x *oxiaClientRangeScanClient) Recv() (*RangeScanResponse, error) {
+	m := new(RangeScanResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *oxiaClientClient) CountRange(ctx context.Context, in *CountRangeRequest, opts ...grpc.CallOption) (*CountRangeResponse, error) {
+	out := new(CountRangeResponse)
+	err := c.cc.Invoke(ctx, "/io.streamnative.oxia.proto.OxiaClient/CountRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *oxiaClientClient) GetNotifications(ctx context.Context, in *NotificationsRequest, opts ...grpc.CallOption) (OxiaClient_GetNotificationsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &OxiaClient_ServiceDesc.Streams[3], "/io.streamnative.oxia.proto.OxiaClient/GetNotifications", opts...)
+	stream, err := c.cc.NewStream(ctx, &OxiaClient_ServiceDesc.Streams[4], "/io.streamnative.oxia.proto.OxiaClient/GetNotifications", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -262,6 +316,19 @@ type OxiaClientServer interface {
 	//
 	// Clients should send an equivalent request to all respective shards.
 	List(*ListRequest, OxiaClient_ListServer) error
+	// *
+	// Requests all the records between a range of keys, including their values
+	// and versions.
+	//
+	// Clients should send an equivalent request to all respective shards.
+	RangeScan(*RangeScanRequest, OxiaClient_RangeScanServer) error
+	// *
+	// Counts the records between a range of keys, and the total size of
+	// their values.
+	//
+	// Clients should send an equivalent request to all respective shards,
+	// and aggregate the responses.
+	CountRange(context.Context, *CountRangeRequest) (*CountRangeResponse, error)
 	GetNotifications(*NotificationsRequest, OxiaClient_GetNotificationsServer) error
 	// Creates a new client session. Sessions are kept alive by regularly sending
 	// heartbeats via the KeepAlive rpc.
@@ -289,6 +356,12 @@ func (UnimplementedOxiaClientServer) Read(*ReadRequest, OxiaClient_ReadServer) e
 func (UnimplementedOxiaClientServer) List(*ListRequest, OxiaClient_ListServer) error {
 	return status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedOxiaClientServer) RangeScan(*RangeScanRequest, OxiaClient_RangeScanServer) error {
+	return status.Errorf(codes.Unimplemented, "method RangeScan not implemented")
+}
+func (UnimplementedOxiaClientServer) CountRange(context.Context, *CountRangeRequest) (*CountRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountRange not implemented")
+}
 func (UnimplementedOxiaClientServer) GetNotifications(*NotificationsRequest, OxiaClient_GetNotificationsServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetNotifications not implemented")
 }
@@ -395,6 +468,45 @@ func (x *oxiaClientListServer) Send(m *ListResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _OxiaClient_RangeScan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RangeScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OxiaClientServer).RangeScan(m, &oxiaClientRangeScanServer{stream})
+}
+
+type OxiaClient_RangeScanServer interface {
+	Send(*RangeScanResponse) error
+	grpc.ServerStream
+}
+
+type oxiaClientRangeScanServer struct {
+	grpc.ServerStream
+}
+
+func (x *oxiaClientRangeScanServer) Send(m *RangeScanResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _OxiaClient_CountRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OxiaClientServer).CountRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.streamnative.oxia.proto.OxiaClient/CountRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OxiaClientServer).CountRange(ctx, req.(*CountRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OxiaClient_GetNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(NotificationsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -481,6 +593,10 @@ var OxiaClient_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Write",
 			Handler:    _OxiaClient_Write_Handler,
 		},
+		{
+			MethodName: "CountRange",
+			Handler:    _OxiaClient_CountRange_Handler,
+		},
 		{
 			MethodName: "CreateSession",
 			Handler:    _OxiaClient_CreateSession_Handler,
@@ -510,6 +626,11 @@ var OxiaClient_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _OxiaClient_List_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "RangeScan",
+			Handler:       _OxiaClient_RangeScan_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetNotifications",
 			Handler:       _OxiaClient_GetNotifications_Handler,
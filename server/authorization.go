@@ -0,0 +1,167 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oxia/common"
+	"oxia/common/metrics"
+	"oxia/common/security"
+	"oxia/proto"
+	"sync"
+)
+
+var ErrorPermissionDenied = status.Error(codes.PermissionDenied, "oxia: permission denied")
+
+// authorization checks the calls of the public service against the permissions
+// of the principal of the client, in the namespace of the shard. When the
+// authorizer is nil, all the calls are allowed.
+type authorization struct {
+	sync.Mutex
+
+	authorizer *security.Authorizer
+	denied     map[string]metrics.Counter
+	log        zerolog.Logger
+}
+
+func newAuthorization(authorizer *security.Authorizer) *authorization {
+	return &authorization{
+		authorizer: authorizer,
+		denied:     make(map[string]metrics.Counter),
+		log: log.With().
+			Str("component", "authorization").
+			Logger(),
+	}
+}
+
+func (a *authorization) permissions(ctx context.Context, lc LeaderController, operation security.Operation) security.Permissions {
+	if a.authorizer == nil {
+		return security.AllowAll
+	}
+
+	principal, _ := security.PrincipalFromContext(ctx)
+	return a.authorizer.Permissions(principal, lc.Namespace(), operation)
+}
+
+// deny records the denial of the call, and returns the error for the client
+func (a *authorization) deny(ctx context.Context, lc LeaderController, operation security.Operation) error {
+	a.logDenial(ctx, lc, operation).Msg("Denied call")
+	a.deniedCounter(lc, operation).Inc()
+	return ErrorPermissionDenied
+}
+
+// denyOperations records the denial of some of the operations of a call, which
+// are reported to the client in their own status
+func (a *authorization) denyOperations(ctx context.Context, lc LeaderController, operation security.Operation, count int) {
+	a.logDenial(ctx, lc, operation).
+		Int("denied-operations", count).
+		Msg("Denied operations")
+	a.deniedCounter(lc, operation).Add(count)
+}
+
+func (a *authorization) logDenial(ctx context.Context, lc LeaderController, operation security.Operation) *zerolog.Event {
+	principal, _ := security.PrincipalFromContext(ctx)
+	return a.log.Warn().
+		Str("peer", common.GetPeer(ctx)).
+		Str("principal", principal).
+		Str("namespace", lc.Namespace()).
+		Int64("shard", lc.ShardId()).
+		Str("operation", string(operation))
+}
+
+func (a *authorization) deniedCounter(lc LeaderController, operation security.Operation) metrics.Counter {
+	a.Lock()
+	defer a.Unlock()
+
+	key := fmt.Sprintf("%s/%d/%s", lc.Namespace(), lc.ShardId(), operation)
+	counter, ok := a.denied[key]
+	if !ok {
+		labels := metrics.LabelsForShard(lc.Namespace(), lc.ShardId())
+		labels["operation"] = string(operation)
+		counter = metrics.NewCounter("oxia_server_authorization_denied",
+			"The number of calls and operations denied by the authorization rules", metrics.Dimensionless, labels)
+		a.denied[key] = counter
+	}
+	return counter
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func deniedWrites(p security.Permissions, req *proto.WriteRequest) *writeRejections {
+	return rejectWrites(req,
+		func(put *proto.PutRequest) proto.Status { return allowedStatus(p.AllowsKey(put.Key)) },
+		func(del *proto.DeleteRequest) proto.Status { return allowedStatus(p.AllowsKey(del.Key)) },
+		func(dr *proto.DeleteRangeRequest) proto.Status {
+			return allowedStatus(p.AllowsRange(dr.StartInclusive, dr.EndExclusive))
+		})
+}
+
+// deniedGets returns the status of each get of the request, and the number of
+// the denied ones.
+//
+// The floor, ceiling, lower and higher lookups are denied unless all the keys
+// are allowed, since the nearest key could be one that is not allowed, even if
+// an allowed one exists beyond it.
+func deniedGets(p security.Permissions, req *proto.ReadRequest) (statuses []proto.Status, count int) {
+	allowsAllKeys := p.AllowsRange("", "\xff/")
+	statuses = checkOperations(req.Gets, func(get *proto.GetRequest) proto.Status {
+		if get.ComparisonType != proto.KeyComparisonType_EQUAL {
+			return allowedStatus(allowsAllKeys)
+		}
+		return allowedStatus(p.AllowsKey(get.Key))
+	}, &count)
+	return statuses, count
+}
+
+func allowedStatus(allowed bool) proto.Status {
+	if allowed {
+		return proto.Status_OK
+	}
+	return proto.Status_PERMISSION_DENIED
+}
+
+// allowsNotifications checks the keys selected by the filters of the request,
+// which must all be covered, either by the prefixes or by the range
+func allowsNotifications(p security.Permissions, req *proto.NotificationsRequest) bool {
+	if len(req.KeyPrefixes) > 0 {
+		allowed := true
+		for _, prefix := range req.KeyPrefixes {
+			allowed = allowed && p.AllowsPrefix(prefix)
+		}
+		if allowed {
+			return true
+		}
+	}
+
+	// Without bounds, the range covers all the keys, since "\xff/" sorts after
+	// any valid UTF-8 key
+	start, end := "", "\xff/"
+	if req.KeyMinInclusive != nil {
+		start = *req.KeyMinInclusive
+	}
+	if req.KeyMaxExclusive != nil {
+		end = *req.KeyMaxExclusive
+	}
+	return p.AllowsRange(start, end)
+}
+
+func allowsNamespace(p security.Permissions) bool {
+	return !p.IsEmpty()
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/stretchr/testify/assert"
+	pb "google.golang.org/protobuf/proto"
+	"oxia/common/security"
+	"oxia/proto"
+	"testing"
+)
+
+func TestAuthorization_Write(t *testing.T) {
+	p := security.Permissions{"/billing/"}
+
+	denials := deniedWrites(p, &proto.WriteRequest{
+		Puts:         []*proto.PutRequest{{Key: "/billing/a"}},
+		Deletes:      []*proto.DeleteRequest{{Key: "/billing/b"}},
+		DeleteRanges: []*proto.DeleteRangeRequest{{StartInclusive: "/billing/c", EndExclusive: "/billing/d"}},
+	})
+	assert.Equal(t, 0, denials.count)
+
+	denials = deniedWrites(p, &proto.WriteRequest{
+		Puts:         []*proto.PutRequest{{Key: "/other/a"}},
+		Deletes:      []*proto.DeleteRequest{{Key: "/other/a"}},
+		DeleteRanges: []*proto.DeleteRangeRequest{{StartInclusive: "/billing/a", EndExclusive: "/other/a"}},
+	})
+	assert.Equal(t, 3, denials.count)
+	assert.True(t, denials.all())
+
+	assert.Equal(t, 0, deniedWrites(security.AllowAll, &proto.WriteRequest{
+		Puts: []*proto.PutRequest{{Key: "/other/a"}},
+	}).count)
+	assert.Equal(t, 1, deniedWrites(nil, &proto.WriteRequest{
+		Puts: []*proto.PutRequest{{Key: "/billing/a"}},
+	}).count)
+}
+
+func TestAuthorization_WriteMixedKeys(t *testing.T) {
+	p := security.Permissions{"/billing/"}
+
+	request := &proto.WriteRequest{
+		Puts:         []*proto.PutRequest{{Key: "/other/a"}, {Key: "/billing/a"}, {Key: "/other/b"}, {Key: "/billing/b"}},
+		Deletes:      []*proto.DeleteRequest{{Key: "/billing/c"}, {Key: "/other/c"}},
+		DeleteRanges: []*proto.DeleteRangeRequest{{StartInclusive: "/billing/a", EndExclusive: "/other/a"}},
+	}
+	denials := deniedWrites(p, request)
+	assert.Equal(t, 4, denials.count)
+	assert.False(t, denials.all())
+
+	// Only the allowed operations are applied
+	denials.remove(request)
+	assert.Equal(t, []*proto.PutRequest{{Key: "/billing/a"}, {Key: "/billing/b"}}, request.Puts)
+	assert.Equal(t, []*proto.DeleteRequest{{Key: "/billing/c"}}, request.Deletes)
+	assert.Empty(t, request.DeleteRanges)
+
+	// The denials are reported in the position of the operations
+	response := denials.merge(&proto.WriteResponse{
+		Puts:    []*proto.PutResponse{{Status: proto.Status_OK}, {Status: proto.Status_UNEXPECTED_VERSION_ID}},
+		Deletes: []*proto.DeleteResponse{{Status: proto.Status_KEY_NOT_FOUND}},
+	})
+	assert.Equal(t, &proto.WriteResponse{
+		Puts: []*proto.PutResponse{
+			{Status: proto.Status_PERMISSION_DENIED},
+			{Status: proto.Status_OK},
+			{Status: proto.Status_PERMISSION_DENIED},
+			{Status: proto.Status_UNEXPECTED_VERSION_ID},
+		},
+		Deletes: []*proto.DeleteResponse{
+			{Status: proto.Status_KEY_NOT_FOUND},
+			{Status: proto.Status_PERMISSION_DENIED},
+		},
+		DeleteRanges: []*proto.DeleteRangeResponse{
+			{Status: proto.Status_PERMISSION_DENIED},
+		},
+	}, response)
+
+	// In a transaction, none of the operations is applied
+	response = deniedWrites(p, &proto.WriteRequest{
+		Puts:        []*proto.PutRequest{{Key: "/billing/a"}, {Key: "/other/a"}},
+		Transaction: true,
+	}).reject()
+	assert.Equal(t, &proto.WriteResponse{
+		Puts: []*proto.PutResponse{
+			{Status: proto.Status_TRANSACTION_ABORTED},
+			{Status: proto.Status_PERMISSION_DENIED},
+		},
+		Deletes:      []*proto.DeleteResponse{},
+		DeleteRanges: []*proto.DeleteRangeResponse{},
+	}, response)
+}
+
+func TestAuthorization_Read(t *testing.T) {
+	p := security.Permissions{"/billing/"}
+
+	statuses, count := deniedGets(p, &proto.ReadRequest{Gets: []*proto.GetRequest{{Key: "/billing/a"}}})
+	assert.Equal(t, []proto.Status{proto.Status_OK}, statuses)
+	assert.Equal(t, 0, count)
+
+	request := &proto.ReadRequest{Gets: []*proto.GetRequest{{Key: "/other/a"}, {Key: "/billing/a"}, {Key: "/other/b"}}}
+	statuses, count = deniedGets(p, request)
+	assert.Equal(t, []proto.Status{proto.Status_PERMISSION_DENIED, proto.Status_OK, proto.Status_PERMISSION_DENIED}, statuses)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []*proto.GetRequest{{Key: "/billing/a"}}, withoutRejected(statuses, request.Gets))
+
+	// The lookups of the nearest keys require the permissions on all the keys
+	request = &proto.ReadRequest{Gets: []*proto.GetRequest{
+		{Key: "/billing/b", ComparisonType: proto.KeyComparisonType_FLOOR},
+		{Key: "/billing/b", ComparisonType: proto.KeyComparisonType_HIGHER},
+	}}
+	statuses, count = deniedGets(p, request)
+	assert.Equal(t, []proto.Status{proto.Status_PERMISSION_DENIED, proto.Status_PERMISSION_DENIED}, statuses)
+	assert.Equal(t, 2, count)
+
+	statuses, count = deniedGets(security.AllowAll, request)
+	assert.Equal(t, []proto.Status{proto.Status_OK, proto.Status_OK}, statuses)
+	assert.Equal(t, 0, count)
+}
+
+func TestAuthorization_Notifications(t *testing.T) {
+	p := security.Permissions{"/billing/"}
+
+	assert.True(t, allowsNotifications(p, &proto.NotificationsRequest{
+		KeyPrefixes: []string{"/billing/eu/", "/billing/us"},
+	}))
+	assert.True(t, allowsNotifications(p, &proto.NotificationsRequest{
+		KeyMinInclusive: pb.String("/billing/a"),
+		KeyMaxExclusive: pb.String("/billing/z"),
+	}))
+	// The prefixes are not all allowed, but the range is
+	assert.True(t, allowsNotifications(p, &proto.NotificationsRequest{
+		KeyPrefixes:     []string{"/other/"},
+		KeyMinInclusive: pb.String("/billing/a"),
+		KeyMaxExclusive: pb.String("/billing/z"),
+	}))
+
+	assert.False(t, allowsNotifications(p, &proto.NotificationsRequest{}))
+	assert.False(t, allowsNotifications(p, &proto.NotificationsRequest{
+		KeyPrefixes: []string{"/billing/", "/other/"},
+	}))
+	assert.False(t, allowsNotifications(p, &proto.NotificationsRequest{
+		KeyMinInclusive: pb.String("/billing/a"),
+	}))
+
+	assert.True(t, allowsNotifications(security.AllowAll, &proto.NotificationsRequest{}))
+}
+
+func TestAuthorization_Session(t *testing.T) {
+	assert.True(t, allowsNamespace(security.Permissions{"/billing/"}))
+	assert.True(t, allowsNamespace(security.AllowAll))
+	assert.False(t, allowsNamespace(nil))
+}
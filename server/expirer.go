@@ -0,0 +1,150 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"oxia/common"
+	"oxia/common/metrics"
+	"oxia/proto"
+	"time"
+)
+
+const (
+	expirationCheckInterval   = 1 * time.Second
+	maxExpiredRecordsPerWrite = 1000
+)
+
+// The expirer runs on the leader and deletes the records whose ttl has elapsed.
+// The deletes are appended to the wal as regular write requests, so that the
+// expiration is applied in the same way on all the replicas.
+type expirer struct {
+	ctx              context.Context
+	cancel           context.CancelFunc
+	leaderController *leaderController
+	shardId          int64
+	interval         time.Duration
+	clock            common.Clock
+	log              zerolog.Logger
+
+	expiredRecords metrics.Counter
+}
+
+func newExpirer(namespace string, shardId int64, controller *leaderController, interval time.Duration, clock common.Clock) *expirer {
+	e := &expirer{
+		leaderController: controller,
+		shardId:          shardId,
+		interval:         interval,
+		clock:            clock,
+		log: log.With().
+			Str("component", "expirer").
+			Str("namespace", namespace).
+			Int64("shard", shardId).
+			Int64("term", controller.term).
+			Logger(),
+
+		expiredRecords: metrics.NewCounter("oxia_server_records_expired",
+			"The total number of records deleted after their ttl has elapsed", "count",
+			metrics.LabelsForShard(namespace, shardId)),
+	}
+	e.ctx, e.cancel = context.WithCancel(controller.ctx)
+
+	go common.DoWithLabels(map[string]string{
+		"oxia":      "expirer",
+		"namespace": namespace,
+		"shard":     fmt.Sprintf("%d", shardId),
+	}, e.run)
+
+	return e
+}
+
+func (e *expirer) run() {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.expireRecords(); err != nil {
+				e.log.Warn().Err(err).
+					Msg("Failed to delete expired records")
+			}
+
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+func (e *expirer) expireRecords() error {
+	for e.ctx.Err() == nil {
+		deletes, orphans, err := e.readExpiredRecords()
+		if err != nil || len(deletes)+len(orphans) == 0 {
+			return err
+		}
+
+		e.log.Debug().
+			Int("count", len(deletes)).
+			Int("orphans", len(orphans)).
+			Msg("Deleting expired records")
+
+		// The orphaned index entries are internal keys, which are not deleted as
+		// client writes, but directly when the log entry is applied
+		task := NewWriteTask(func(_ int64) *proto.WriteRequest {
+			return &proto.WriteRequest{
+				ShardId: &e.shardId,
+				Deletes: deletes,
+			}
+		}, false)
+		task.orphanedExpirationIndexKeys = orphans
+		_, res, err := e.leaderController.writeTask(task)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range res.Deletes {
+			if r.Status == proto.Status_OK {
+				e.expiredRecords.Inc()
+			}
+		}
+
+		if len(deletes)+len(orphans) < maxExpiredRecordsPerWrite {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (e *expirer) readExpiredRecords() (deletes []*proto.DeleteRequest, orphans []string, err error) {
+	lc := e.leaderController
+	lc.RLock()
+	defer lc.RUnlock()
+
+	if lc.status != proto.ServingStatus_LEADER {
+		return nil, nil, nil
+	}
+
+	now := uint64(e.clock.Now().UnixMilli())
+	return lc.db.ReadExpiredRecords(now, maxExpiredRecordsPerWrite)
+}
+
+// Close stops the expirer, without waiting for an ongoing write to complete
+func (e *expirer) Close() error {
+	e.cancel()
+	return nil
+}
@@ -133,7 +133,7 @@ func NewFollowerController(config Config, namespace string, shardId int64, wf wa
 	fc.walTrimmer = wal.NewTrimmer(namespace, shardId, fc.wal, config.WalRetentionTime, wal.DefaultCheckInterval,
 		common.SystemClock, fc)
 
-	if fc.db, err = kv.NewDB(namespace, shardId, kvFactory, config.NotificationsRetentionTime, common.SystemClock); err != nil {
+	if fc.db, err = kv.NewDB(namespace, shardId, kvFactory, config.NotificationsRetentionTime, config.NotificationsIncludeValues, common.SystemClock); err != nil {
 		return nil, err
 	}
 
@@ -514,6 +514,10 @@ func (fc *followerController) processCommittedEntries(maxInclusive int64) error
 				return err
 			}
 		}
+		if err = fc.db.DeleteOrphanedExpirationIndexKeys(value.GetRequests().OrphanedExpirationIndexKeys); err != nil {
+			fc.log.Err(err).Msg("Error applying committed entry")
+			return err
+		}
 		fc.commitOffset.Store(entry.Offset)
 	}
 
@@ -622,7 +626,7 @@ func (fc *followerController) handleSnapshot(stream proto.OxiaLogReplication_Sen
 	// We have received all the files for the database
 	loader.Complete()
 
-	newDb, err := kv.NewDB(fc.namespace, fc.shardId, fc.kvFactory, fc.config.NotificationsRetentionTime, common.SystemClock)
+	newDb, err := kv.NewDB(fc.namespace, fc.shardId, fc.kvFactory, fc.config.NotificationsRetentionTime, fc.config.NotificationsIncludeValues, common.SystemClock)
 	if err != nil {
 		fc.closeStreamNoMutex(errors.Wrap(err, "failed to open database after loading snapshot"))
 		return
@@ -201,7 +201,7 @@ func TestFollower_RestoreCommitOffset(t *testing.T) {
 	assert.NoError(t, err)
 	walFactory := wal.NewWalFactory(&wal.WalFactoryOptions{LogDir: t.TempDir()})
 
-	db, err := kv.NewDB(common.DefaultNamespace, shardId, kvFactory, 1*time.Hour, common.SystemClock)
+	db, err := kv.NewDB(common.DefaultNamespace, shardId, kvFactory, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 	_, err = db.ProcessWrite(&proto.WriteRequest{Puts: []*proto.PutRequest{{
 		Key:   "xx",
@@ -452,7 +452,7 @@ func TestFollowerController_RejectEntriesWithDifferentTerm(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	db, err := kv.NewDB(common.DefaultNamespace, shardId, kvFactory, 1*time.Hour, common.SystemClock)
+	db, err := kv.NewDB(common.DefaultNamespace, shardId, kvFactory, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 	// Force a new term in the DB before opening
 	assert.NoError(t, db.UpdateTerm(5))
@@ -558,7 +558,7 @@ func prepareTestDb(t *testing.T) kv.Snapshot {
 		DataDir: t.TempDir(),
 	})
 	assert.NoError(t, err)
-	db, err := kv.NewDB(common.DefaultNamespace, 0, kvFactory, 1*time.Hour, common.SystemClock)
+	db, err := kv.NewDB(common.DefaultNamespace, 0, kvFactory, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	for i := 0; i < 100; i++ {
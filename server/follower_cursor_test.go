@@ -35,7 +35,7 @@ func TestFollowerCursor(t *testing.T) {
 	ackTracker := NewQuorumAckTracker(3, wal.InvalidOffset, wal.InvalidOffset)
 	kvf, err := kv.NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := kv.NewDB(common.DefaultNamespace, shard, kvf, 1*time.Hour, common.SystemClock)
+	db, err := kv.NewDB(common.DefaultNamespace, shard, kvf, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 	wf := wal.NewWalFactory(&wal.WalFactoryOptions{LogDir: t.TempDir()})
 	w, err := wf.NewWal(common.DefaultNamespace, shard)
@@ -117,7 +117,7 @@ func TestFollowerCursor_SendSnapshot(t *testing.T) {
 	stream := newMockRpcClient()
 	kvf, err := kv.NewPebbleKVFactory(&kv.KVFactoryOptions{DataDir: t.TempDir()})
 	assert.NoError(t, err)
-	db, err := kv.NewDB(common.DefaultNamespace, shard, kvf, 1*time.Hour, common.SystemClock)
+	db, err := kv.NewDB(common.DefaultNamespace, shard, kvf, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 	wf := wal.NewWalFactory(&wal.WalFactoryOptions{LogDir: t.TempDir()})
 	w, err := wf.NewWal(common.DefaultNamespace, shard)
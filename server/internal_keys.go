@@ -0,0 +1,117 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"oxia/common"
+	"oxia/proto"
+)
+
+// clipInternalRange returns the sub-ranges of [start, end) that don't contain
+// any internal key, in ascending order. It returns false for a range that
+// only contains internal keys, while an empty range is returned as is.
+func clipInternalRange(start, end string) ([]common.KeyRange, bool) {
+	if common.CompareWithSlash([]byte(start), []byte(end)) >= 0 {
+		return []common.KeyRange{{Start: start, End: end}}, true
+	}
+
+	ranges := common.UserKeyRanges(start, end)
+	return ranges, len(ranges) > 0
+}
+
+// rejectInternalKeys rejects the client writes to the internal keys before
+// they get appended to the wal, since they could corrupt the state of the
+// shard. The puts, the deletes and the delete ranges that only contain internal
+// keys fail with the INVALID_KEY status, without affecting the other operations
+// of the request.
+func rejectInternalKeys(request *proto.WriteRequest) *writeRejections {
+	return rejectWrites(request,
+		func(put *proto.PutRequest) proto.Status { return keyStatus(put.Key) },
+		func(del *proto.DeleteRequest) proto.Status { return keyStatus(del.Key) },
+		func(dr *proto.DeleteRangeRequest) proto.Status {
+			if _, ok := clipInternalRange(dr.StartInclusive, dr.EndExclusive); !ok {
+				return proto.Status_INVALID_KEY
+			}
+			return proto.Status_OK
+		})
+}
+
+func keyStatus(key string) proto.Status {
+	if common.IsInternalKey(key) {
+		return proto.Status_INVALID_KEY
+	}
+	return proto.Status_OK
+}
+
+// clipInternalKeys clips the delete ranges that partially overlap the internal
+// keys, after the ones entirely within them were removed by rejectInternalKeys.
+//
+// The delete ranges are split in two when they span all the internal keys. In
+// that case, it returns the number of delete ranges that each of the original
+// ones was turned into, for their responses to be merged back with
+// mergeDeleteRanges.
+func clipInternalKeys(request *proto.WriteRequest) (splits []int) {
+	clipped := false
+	deleteRanges := make([]*proto.DeleteRangeRequest, 0, len(request.DeleteRanges))
+	splits = make([]int, len(request.DeleteRanges))
+	for i, dr := range request.DeleteRanges {
+		ranges, _ := clipInternalRange(dr.StartInclusive, dr.EndExclusive)
+
+		if len(ranges) == 1 && ranges[0].Start == dr.StartInclusive && ranges[0].End == dr.EndExclusive {
+			deleteRanges = append(deleteRanges, dr)
+		} else {
+			clipped = true
+			for _, r := range ranges {
+				deleteRanges = append(deleteRanges, &proto.DeleteRangeRequest{
+					StartInclusive: r.Start,
+					EndExclusive:   r.End,
+				})
+			}
+		}
+		splits[i] = len(ranges)
+	}
+
+	if !clipped {
+		return nil
+	}
+	request.DeleteRanges = deleteRanges
+	return splits
+}
+
+// mergeDeleteRanges restores one delete range response per delete range of the
+// client request, after they were split by clipInternalKeys. A merged response
+// has the first status that is not OK, if any.
+func mergeDeleteRanges(response *proto.WriteResponse, splits []int) *proto.WriteResponse {
+	if splits == nil {
+		return response
+	}
+
+	merged := make([]*proto.DeleteRangeResponse, 0, len(splits))
+	offset := 0
+	for _, count := range splits {
+		res := &proto.DeleteRangeResponse{Status: proto.Status_OK}
+		for _, r := range response.DeleteRanges[offset : offset+count] {
+			if r.Status != proto.Status_OK {
+				res = r
+				break
+			}
+		}
+		merged = append(merged, res)
+		offset += count
+	}
+
+	response.DeleteRanges = merged
+	return response
+}
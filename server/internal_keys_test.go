@@ -0,0 +1,114 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oxia/common"
+	"oxia/proto"
+	"testing"
+)
+
+func TestClipInternalRange(t *testing.T) {
+	for _, test := range []struct {
+		start    string
+		end      string
+		expected []common.KeyRange
+	}{
+		{"/a", "/z", []common.KeyRange{{Start: "/a", End: "/z"}}},
+		{"b/a", "b/z", []common.KeyRange{{Start: "b/a", End: "b/z"}}},
+		{"/z", "/a", []common.KeyRange{{Start: "/z", End: "/a"}}},
+		{"/a", "__oxia/term", []common.KeyRange{{Start: "/a", End: "__oxia/"}}},
+		{"__oxia/term", "b/z", []common.KeyRange{{Start: "__oxia\x00/", End: "b/z"}}},
+		{"", "\xff/", []common.KeyRange{{Start: "", End: "__oxia/"}, {Start: "__oxia\x00/", End: "\xff/"}}},
+	} {
+		ranges, ok := clipInternalRange(test.start, test.end)
+		assert.True(t, ok)
+		assert.Equal(t, test.expected, ranges, "[%q, %q)", test.start, test.end)
+	}
+
+	_, ok := clipInternalRange("__oxia/a", "__oxia/z")
+	assert.False(t, ok)
+
+	_, ok = clipInternalRange(common.InternalKeyPrefix, common.InternalKeysEnd)
+	assert.False(t, ok)
+}
+
+func TestRejectInternalKeys(t *testing.T) {
+	request := &proto.WriteRequest{
+		Puts:    []*proto.PutRequest{{Key: "/a"}, {Key: "__oxia-like"}},
+		Deletes: []*proto.DeleteRequest{{Key: "/b"}},
+		DeleteRanges: []*proto.DeleteRangeRequest{
+			{StartInclusive: "/c", EndExclusive: "/d"},
+			{StartInclusive: "", EndExclusive: "\xff/"},
+		},
+	}
+	assert.Equal(t, 0, rejectInternalKeys(request).count)
+
+	request = &proto.WriteRequest{
+		Puts:    []*proto.PutRequest{{Key: "/a"}, {Key: "__oxia/term"}},
+		Deletes: []*proto.DeleteRequest{{Key: "__oxia/commit-offset"}, {Key: "/b"}},
+		DeleteRanges: []*proto.DeleteRangeRequest{
+			{StartInclusive: "__oxia/session/", EndExclusive: "__oxia/session//"},
+			{StartInclusive: "/c", EndExclusive: "/d"},
+		},
+	}
+	rejections := rejectInternalKeys(request)
+	assert.Equal(t, 3, rejections.count)
+	assert.Equal(t, []proto.Status{proto.Status_OK, proto.Status_INVALID_KEY}, rejections.puts)
+	assert.Equal(t, []proto.Status{proto.Status_INVALID_KEY, proto.Status_OK}, rejections.deletes)
+	assert.Equal(t, []proto.Status{proto.Status_INVALID_KEY, proto.Status_OK}, rejections.deleteRanges)
+}
+
+func TestClipInternalKeys(t *testing.T) {
+	request := &proto.WriteRequest{
+		Puts:    []*proto.PutRequest{{Key: "/a"}, {Key: "__oxia-like"}},
+		Deletes: []*proto.DeleteRequest{{Key: "/b"}},
+		DeleteRanges: []*proto.DeleteRangeRequest{
+			{StartInclusive: "/c", EndExclusive: "/d"},
+		},
+	}
+	assert.Nil(t, clipInternalKeys(request))
+	assert.Len(t, request.DeleteRanges, 1)
+
+	request = &proto.WriteRequest{
+		DeleteRanges: []*proto.DeleteRangeRequest{
+			{StartInclusive: "/a", EndExclusive: "/b"},
+			{StartInclusive: "", EndExclusive: "\xff/"},
+			{StartInclusive: "__oxia/term", EndExclusive: "b/z"},
+		},
+	}
+	splits := clipInternalKeys(request)
+	assert.Equal(t, []int{1, 2, 1}, splits)
+	assert.Equal(t, []*proto.DeleteRangeRequest{
+		{StartInclusive: "/a", EndExclusive: "/b"},
+		{StartInclusive: "", EndExclusive: "__oxia/"},
+		{StartInclusive: "__oxia\x00/", EndExclusive: "\xff/"},
+		{StartInclusive: "__oxia\x00/", EndExclusive: "b/z"},
+	}, request.DeleteRanges)
+
+	response := mergeDeleteRanges(&proto.WriteResponse{
+		DeleteRanges: []*proto.DeleteRangeResponse{
+			{Status: proto.Status_OK},
+			{Status: proto.Status_OK},
+			{Status: proto.Status_TRANSACTION_ABORTED},
+			{Status: proto.Status_OK},
+		},
+	}, splits)
+	assert.Len(t, response.DeleteRanges, 3)
+	assert.Equal(t, proto.Status_OK, response.DeleteRanges[0].Status)
+	assert.Equal(t, proto.Status_TRANSACTION_ABORTED, response.DeleteRanges[1].Status)
+	assert.Equal(t, proto.Status_OK, response.DeleteRanges[2].Status)
+}
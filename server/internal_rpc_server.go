@@ -16,6 +16,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -29,6 +30,7 @@ import (
 	"io"
 	"oxia/common"
 	"oxia/common/container"
+	"oxia/common/security"
 	"oxia/proto"
 )
 
@@ -43,7 +45,13 @@ type internalRpcServer struct {
 	log                  zerolog.Logger
 }
 
-func newInternalRpcServer(grpcProvider container.GrpcProvider, bindAddress string, shardsDirector ShardsDirector, assignmentDispatcher ShardAssignmentsDispatcher) (*internalRpcServer, error) {
+// newInternalRpcServer starts the internal service. When the peer verifier is
+// set, only the calls from the allowed coordinator and servers are accepted,
+// except for the health service, which stays open to the liveness and
+// readiness probes.
+func newInternalRpcServer(grpcProvider container.GrpcProvider, bindAddress string, tlsConf *tls.Config,
+	peerVerifier *security.PeerVerifier, shardsDirector ShardsDirector,
+	assignmentDispatcher ShardAssignmentsDispatcher) (*internalRpcServer, error) {
 	server := &internalRpcServer{
 		shardsDirector:       shardsDirector,
 		assignmentDispatcher: assignmentDispatcher,
@@ -53,12 +61,17 @@ func newInternalRpcServer(grpcProvider container.GrpcProvider, bindAddress strin
 			Logger(),
 	}
 
+	var options []grpc.ServerOption
+	if peerVerifier != nil {
+		options = peerVerifier.ServerOptions()
+	}
+
 	var err error
 	server.grpcServer, err = grpcProvider.StartGrpcServer("internal", bindAddress, func(registrar grpc.ServiceRegistrar) {
 		proto.RegisterOxiaCoordinationServer(registrar, server)
 		proto.RegisterOxiaLogReplicationServer(registrar, server)
 		grpc_health_v1.RegisterHealthServer(registrar, server.healthServer)
-	})
+	}, tlsConf, options...)
 	if err != nil {
 		return nil, err
 	}
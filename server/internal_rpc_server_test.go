@@ -26,7 +26,7 @@ import (
 )
 
 func TestInternalHealthCheck(t *testing.T) {
-	server, err := newInternalRpcServer(container.Default, "localhost:0", nil, NewShardAssignmentDispatcher())
+	server, err := newInternalRpcServer(container.Default, "localhost:0", nil, nil, nil, NewShardAssignmentDispatcher())
 	assert.NoError(t, err)
 
 	target := fmt.Sprintf("localhost:%d", server.grpcServer.Port())
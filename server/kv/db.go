@@ -26,6 +26,8 @@ import (
 	"oxia/common/metrics"
 	"oxia/proto"
 	"oxia/server/wal"
+	"strconv"
+	"strings"
 	"time"
 
 	pb "google.golang.org/protobuf/proto"
@@ -34,8 +36,9 @@ import (
 var ErrorBadVersionId = errors.New("oxia: bad version id")
 
 const (
-	commitOffsetKey = common.InternalKeyPrefix + "commit-offset"
-	termKey         = common.InternalKeyPrefix + "term"
+	commitOffsetKey      = common.InternalKeyPrefix + "commit-offset"
+	termKey              = common.InternalKeyPrefix + "term"
+	sequentialKeysPrefix = common.InternalKeyPrefix + "sequential-keys/"
 )
 
 type UpdateOperationCallback interface {
@@ -43,15 +46,30 @@ type UpdateOperationCallback interface {
 	OnDelete(WriteBatch, string) error
 }
 
+type RangeScanIterator interface {
+	io.Closer
+
+	Valid() bool
+	Value() (*proto.GetResponse, error)
+	Next() bool
+}
+
 type DB interface {
 	io.Closer
 
 	ProcessWrite(b *proto.WriteRequest, commitOffset int64, timestamp uint64, updateOperationCallback UpdateOperationCallback) (*proto.WriteResponse, error)
 	Get(request *proto.GetRequest) (*proto.GetResponse, error)
 	List(request *proto.ListRequest) KeyIterator
+	ListInternal(request *proto.ListRequest) KeyIterator
+	RangeScan(request *proto.RangeScanRequest) RangeScanIterator
+	CountRange(request *proto.CountRangeRequest) (*proto.CountRangeResponse, error)
 	ReadCommitOffset() (int64, error)
 
 	ReadNextNotifications(ctx context.Context, startOffset int64) ([]*proto.NotificationBatch, error)
+	CheckNotificationsOffset(startOffset int64) error
+
+	ReadExpiredRecords(timestamp uint64, maxCount int) (deletes []*proto.DeleteRequest, orphans []string, err error)
+	DeleteOrphanedExpirationIndexKeys(indexKeys []string) error
 
 	UpdateTerm(newTerm int64) error
 	ReadTerm() (term int64, err error)
@@ -62,7 +80,10 @@ type DB interface {
 	Delete() error
 }
 
-func NewDB(namespace string, shardId int64, factory KVFactory, notificationRetentionTime time.Duration, clock common.Clock) (DB, error) {
+// NewDB opens the database of a shard. The notifications carry the values of
+// the records only when notificationsIncludeValues is set, since they are stored
+// for the whole retention time.
+func NewDB(namespace string, shardId int64, factory KVFactory, notificationRetentionTime time.Duration, notificationsIncludeValues bool, clock common.Clock) (DB, error) {
 	kv, err := factory.NewKV(namespace, shardId)
 	if err != nil {
 		return nil, err
@@ -70,8 +91,9 @@ func NewDB(namespace string, shardId int64, factory KVFactory, notificationReten
 
 	labels := metrics.LabelsForShard(namespace, shardId)
 	db := &db{
-		kv:      kv,
-		shardId: shardId,
+		kv:                         kv,
+		shardId:                    shardId,
+		notificationsIncludeValues: notificationsIncludeValues,
 		log: log.Logger.With().
 			Str("component", "db").
 			Str("namespace", namespace).
@@ -84,6 +106,10 @@ func NewDB(namespace string, shardId int64, factory KVFactory, notificationReten
 			"The time it takes to get from the db", labels),
 		listLatencyHisto: metrics.NewLatencyHistogram("oxia_server_db_list_latency",
 			"The time it takes to read a list from the db", labels),
+		rangeScanLatencyHisto: metrics.NewLatencyHistogram("oxia_server_db_range_scan_latency",
+			"The time it takes to read a range scan from the db", labels),
+		countRangeLatencyHisto: metrics.NewLatencyHistogram("oxia_server_db_count_range_latency",
+			"The time it takes to count a range in the db", labels),
 		putCounter: metrics.NewCounter("oxia_server_db_puts",
 			"The total number of put operations", "count", labels),
 		deleteCounter: metrics.NewCounter("oxia_server_db_deletes",
@@ -94,6 +120,10 @@ func NewDB(namespace string, shardId int64, factory KVFactory, notificationReten
 			"The total number of get operations", "count", labels),
 		listCounter: metrics.NewCounter("oxia_server_db_lists",
 			"The total number of list operations", "count", labels),
+		rangeScanCounter: metrics.NewCounter("oxia_server_db_range_scans",
+			"The total number of range scan operations", "count", labels),
+		countRangeCounter: metrics.NewCounter("oxia_server_db_count_ranges",
+			"The total number of count range operations", "count", labels),
 	}
 
 	commitOffset, err := db.ReadCommitOffset()
@@ -106,20 +136,25 @@ func NewDB(namespace string, shardId int64, factory KVFactory, notificationReten
 }
 
 type db struct {
-	kv                   KV
-	shardId              int64
-	notificationsTracker *notificationsTracker
-	log                  zerolog.Logger
+	kv                         KV
+	shardId                    int64
+	notificationsTracker       *notificationsTracker
+	notificationsIncludeValues bool
+	log                        zerolog.Logger
 
 	putCounter          metrics.Counter
 	deleteCounter       metrics.Counter
 	deleteRangesCounter metrics.Counter
 	getCounter          metrics.Counter
 	listCounter         metrics.Counter
+	rangeScanCounter    metrics.Counter
+	countRangeCounter   metrics.Counter
 
 	batchWriteLatencyHisto metrics.LatencyHistogram
 	getLatencyHisto        metrics.LatencyHistogram
 	listLatencyHisto       metrics.LatencyHistogram
+	rangeScanLatencyHisto  metrics.LatencyHistogram
+	countRangeLatencyHisto metrics.LatencyHistogram
 }
 
 func (d *db) Snapshot() (Snapshot, error) {
@@ -148,10 +183,59 @@ func (d *db) ProcessWrite(b *proto.WriteRequest, commitOffset int64, timestamp u
 	timer := d.batchWriteLatencyHisto.Timer()
 	defer timer.Done()
 
-	res := &proto.WriteResponse{}
-
 	batch := d.kv.NewWriteBatch()
-	notifications := newNotifications(d.shardId, commitOffset, timestamp)
+	notifications, err := d.newNotifications(commitOffset, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := d.applyWriteRequest(b, commitOffset, batch, notifications, timestamp, updateOperationCallback)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Transaction && !isSuccessful(res) {
+		// One of the operations in the transaction has failed. All the changes
+		// are discarded and we only record that the commit offset has advanced
+		if err := batch.Close(); err != nil {
+			return nil, err
+		}
+
+		batch = d.kv.NewWriteBatch()
+		if notifications, err = d.newNotifications(commitOffset, timestamp); err != nil {
+			return nil, err
+		}
+		abortTransaction(res)
+
+		d.log.Debug().
+			Int64("commit-offset", commitOffset).
+			Msg("Aborted transaction")
+	}
+
+	if err := d.addCommitOffset(commitOffset, batch, timestamp); err != nil {
+		return nil, err
+	}
+
+	// Add the notifications to the batch as well
+	if err := d.addNotifications(batch, notifications); err != nil {
+		return nil, err
+	}
+
+	if err := batch.Commit(); err != nil {
+		return nil, err
+	}
+
+	d.notificationsTracker.UpdatedCommitOffset(commitOffset)
+
+	if err := batch.Close(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (d *db) applyWriteRequest(b *proto.WriteRequest, commitOffset int64, batch WriteBatch, notifications *notifications, timestamp uint64, updateOperationCallback UpdateOperationCallback) (*proto.WriteResponse, error) {
+	res := &proto.WriteResponse{}
 
 	d.putCounter.Add(len(b.Puts))
 	for _, putReq := range b.Puts {
@@ -179,26 +263,70 @@ func (d *db) ProcessWrite(b *proto.WriteRequest, commitOffset int64, timestamp u
 			res.DeleteRanges = append(res.DeleteRanges, dr)
 		}
 	}
-	if err := d.addCommitOffset(commitOffset, batch, timestamp); err != nil {
-		return nil, err
-	}
 
-	// Add the notifications to the batch as well
-	if err := d.addNotifications(batch, notifications); err != nil {
-		return nil, err
+	return res, nil
+}
+
+func isSuccessful(res *proto.WriteResponse) bool {
+	for _, pr := range res.Puts {
+		if pr.Status != proto.Status_OK {
+			return false
+		}
 	}
+	for _, dr := range res.Deletes {
+		if dr.Status != proto.Status_OK {
+			return false
+		}
+	}
+	for _, dr := range res.DeleteRanges {
+		if dr.Status != proto.Status_OK {
+			return false
+		}
+	}
+	return true
+}
 
-	if err := batch.Commit(); err != nil {
-		return nil, err
+// abortTransaction marks all the operations that would have succeeded as aborted,
+// leaving the status of the failed operations untouched.
+func abortTransaction(res *proto.WriteResponse) {
+	for _, pr := range res.Puts {
+		if pr.Status == proto.Status_OK {
+			pr.Status = proto.Status_TRANSACTION_ABORTED
+			pr.Version = nil
+			pr.Value = nil
+			pr.PreviousValue = nil
+			pr.PreviousVersion = nil
+		}
+	}
+	for _, dr := range res.Deletes {
+		if dr.Status == proto.Status_OK {
+			dr.Status = proto.Status_TRANSACTION_ABORTED
+			dr.PreviousValue = nil
+			dr.PreviousVersion = nil
+		}
+	}
+	for _, dr := range res.DeleteRanges {
+		if dr.Status == proto.Status_OK {
+			dr.Status = proto.Status_TRANSACTION_ABORTED
+		}
 	}
+}
 
-	d.notificationsTracker.UpdatedCommitOffset(commitOffset)
+// newNotifications creates the notifications of a write request. All the
+// requests that were appended to the wal in the same entry share its offset,
+// so the notifications of the ones that were already applied are kept
+func (d *db) newNotifications(commitOffset int64, timestamp uint64) (*notifications, error) {
+	notifications := newNotifications(d.shardId, commitOffset, timestamp, d.notificationsIncludeValues)
 
-	if err := batch.Close(); err != nil {
+	value, closer, err := d.kv.Get(notificationKey(commitOffset))
+	if errors.Is(err, ErrorKeyNotFound) {
+		return notifications, nil
+	} else if err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	err = notifications.merge(value)
+	return notifications, multierr.Append(err, closer.Close())
 }
 
 func (d *db) addNotifications(batch WriteBatch, notifications *notifications) error {
@@ -242,14 +370,250 @@ func (it *listIterator) Close() error {
 	return it.KeyIterator.Close()
 }
 
+// reverseKeyIterator adapts a ReverseKeyIterator to the KeyIterator interface,
+// so that descending listings can be consumed like the ascending ones
+type reverseKeyIterator struct {
+	ReverseKeyIterator
+}
+
+func (it *reverseKeyIterator) Next() bool {
+	return it.Prev()
+}
+
+// limitedKeyIterator stops the iteration after a maximum number of keys
+type limitedKeyIterator struct {
+	KeyIterator
+	remaining uint32
+}
+
+func (it *limitedKeyIterator) Valid() bool {
+	return it.remaining > 0 && it.KeyIterator.Valid()
+}
+
+func (it *limitedKeyIterator) Next() bool {
+	if it.remaining > 0 {
+		it.remaining--
+	}
+	return it.remaining > 0 && it.KeyIterator.Next()
+}
+
+// keyRangesIterator lists the keys of the ranges one after the other, so that
+// the internal keys are skipped
+type keyRangesIterator struct {
+	KeyIterator
+	ranges   []common.KeyRange
+	scan     func(r common.KeyRange) KeyIterator
+	closeErr error
+}
+
+func (it *keyRangesIterator) Next() bool {
+	if it.KeyIterator.Next() {
+		return true
+	}
+	return it.nextRange()
+}
+
+// nextRange moves to the first key of the following ranges, if the current one
+// is exhausted
+func (it *keyRangesIterator) nextRange() bool {
+	for !it.KeyIterator.Valid() && len(it.ranges) > 0 {
+		it.closeErr = multierr.Append(it.closeErr, it.KeyIterator.Close())
+		it.KeyIterator = it.scan(it.ranges[0])
+		it.ranges = it.ranges[1:]
+	}
+	return it.KeyIterator.Valid()
+}
+
+func (it *keyRangesIterator) Close() error {
+	return multierr.Append(it.closeErr, it.KeyIterator.Close())
+}
+
+// List returns the keys of the request, skipping the internal keys, which are
+// not visible to the clients
 func (d *db) List(request *proto.ListRequest) KeyIterator {
+	return d.list(request, common.UserKeyRanges)
+}
+
+// ListInternal returns the keys of the request, including the internal keys. It
+// is only used to read the state of the shard, such as the sessions.
+func (d *db) ListInternal(request *proto.ListRequest) KeyIterator {
+	return d.list(request, func(start, end string) []common.KeyRange {
+		return []common.KeyRange{{Start: start, End: end}}
+	})
+}
+
+func (d *db) list(request *proto.ListRequest, keyRanges func(start, end string) []common.KeyRange) KeyIterator {
 	d.listCounter.Add(1)
+
+	start, end := request.StartInclusive, request.EndExclusive
+	if request.Continuation != nil {
+		// The continuation is the last key returned by the previous page
+		lastKey := *request.Continuation
+		if request.Reverse {
+			if common.CompareWithSlash([]byte(lastKey), []byte(end)) < 0 {
+				end = lastKey
+			}
+		} else {
+			// Appending a zero byte gives the smallest key that sorts
+			// after the last one
+			if next := lastKey + "\x00"; common.CompareWithSlash([]byte(next), []byte(start)) > 0 {
+				start = next
+			}
+		}
+	}
+
+	var ranges []common.KeyRange
+	if common.CompareWithSlash([]byte(start), []byte(end)) < 0 {
+		ranges = keyRanges(start, end)
+	}
+
+	scan := func(r common.KeyRange) KeyIterator {
+		return d.kv.KeyRangeScan(r.Start, r.End)
+	}
+	if request.Reverse {
+		reversed := make([]common.KeyRange, 0, len(ranges))
+		for i := len(ranges) - 1; i >= 0; i-- {
+			reversed = append(reversed, ranges[i])
+		}
+		ranges = reversed
+		scan = func(r common.KeyRange) KeyIterator {
+			return &reverseKeyIterator{d.kv.KeyRangeScanReverse(r.Start, r.End)}
+		}
+	}
+
+	// Without any range, the iterator is empty
+	first := common.KeyRange{Start: start, End: start}
+	if len(ranges) > 0 {
+		first, ranges = ranges[0], ranges[1:]
+	}
+	ranged := &keyRangesIterator{
+		KeyIterator: scan(first),
+		ranges:      ranges,
+		scan:        scan,
+	}
+	ranged.nextRange()
+
+	var it KeyIterator = ranged
+	if request.Limit != nil {
+		it = &limitedKeyIterator{KeyIterator: it, remaining: *request.Limit}
+	}
+
 	return &listIterator{
-		KeyIterator: d.kv.KeyRangeScan(request.StartInclusive, request.EndExclusive),
+		KeyIterator: it,
 		timer:       d.listLatencyHisto.Timer(),
 	}
 }
 
+// rangeScanIterator reads the records of the user key ranges one after the
+// other, so that the internal keys are skipped
+type rangeScanIterator struct {
+	KeyValueIterator
+	kv       KV
+	ranges   []common.KeyRange
+	closeErr error
+	timer    metrics.Timer
+}
+
+func (it *rangeScanIterator) Next() bool {
+	if it.KeyValueIterator.Next() {
+		return true
+	}
+	return it.nextRange()
+}
+
+// nextRange moves to the first record of the following ranges, if the current
+// one is exhausted
+func (it *rangeScanIterator) nextRange() bool {
+	for !it.KeyValueIterator.Valid() && len(it.ranges) > 0 {
+		it.closeErr = multierr.Append(it.closeErr, it.KeyValueIterator.Close())
+		it.KeyValueIterator = it.kv.RangeScan(it.ranges[0].Start, it.ranges[0].End)
+		it.ranges = it.ranges[1:]
+	}
+	return it.KeyValueIterator.Valid()
+}
+
+func (it *rangeScanIterator) Value() (*proto.GetResponse, error) {
+	value, err := it.KeyValueIterator.Value()
+	if err != nil {
+		return nil, errors.Wrap(err, "oxia db: failed to read range")
+	}
+
+	se, err := deserialize(value)
+	if err != nil {
+		return nil, err
+	}
+
+	key := it.Key()
+	return &proto.GetResponse{
+		Key:     &key,
+		Value:   se.Value,
+		Version: toVersion(se),
+	}, nil
+}
+
+func (it *rangeScanIterator) Close() error {
+	it.timer.Done()
+	return multierr.Append(it.closeErr, it.KeyValueIterator.Close())
+}
+
+func (d *db) RangeScan(request *proto.RangeScanRequest) RangeScanIterator {
+	d.rangeScanCounter.Add(1)
+
+	// The internal keys are not visible to the clients
+	ranges := common.UserKeyRanges(request.StartInclusive, request.EndExclusive)
+	first := common.KeyRange{Start: request.StartInclusive, End: request.StartInclusive}
+	if len(ranges) > 0 {
+		first, ranges = ranges[0], ranges[1:]
+	}
+
+	it := &rangeScanIterator{
+		KeyValueIterator: d.kv.RangeScan(first.Start, first.End),
+		kv:               d.kv,
+		ranges:           ranges,
+		timer:            d.rangeScanLatencyHisto.Timer(),
+	}
+	it.nextRange()
+	return it
+}
+
+func (d *db) CountRange(request *proto.CountRangeRequest) (*proto.CountRangeResponse, error) {
+	timer := d.countRangeLatencyHisto.Timer()
+	defer timer.Done()
+	d.countRangeCounter.Add(1)
+
+	// The internal keys are not visible to the clients
+	response := &proto.CountRangeResponse{}
+	for _, r := range common.UserKeyRanges(request.StartInclusive, request.EndExclusive) {
+		if err := countRange(d.kv, r, response); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+func countRange(kv KV, r common.KeyRange, response *proto.CountRangeResponse) error {
+	it := kv.RangeScan(r.Start, r.End)
+	defer func() {
+		_ = it.Close()
+	}()
+
+	for ; it.Valid(); it.Next() {
+		value, err := it.Value()
+		if err != nil {
+			return errors.Wrap(err, "oxia db: failed to count range")
+		}
+
+		se, err := deserialize(value)
+		if err != nil {
+			return err
+		}
+
+		response.Count++
+		response.TotalValueSize += int64(len(se.Value))
+	}
+	return nil
+}
+
 func (d *db) ReadCommitOffset() (int64, error) {
 	kv := d.kv
 
@@ -315,6 +679,20 @@ func (d *db) ReadTerm() (term int64, err error) {
 }
 
 func (d *db) applyPut(commitOffset int64, batch WriteBatch, notifications *notifications, putReq *proto.PutRequest, timestamp uint64, updateOperationCallback UpdateOperationCallback) (*proto.PutResponse, error) {
+	var generatedKey *string
+	if len(putReq.SequenceKeyDelta) > 0 {
+		key, err := d.nextSequentialKey(commitOffset, batch, putReq, timestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		// Apply the put on the generated key, without modifying the original request
+		putReq = pb.Clone(putReq).(*proto.PutRequest)
+		putReq.Key = key
+		putReq.SequenceKeyDelta = nil
+		generatedKey = &key
+	}
+
 	se, err := checkExpectedVersionId(batch, putReq.Key, putReq.ExpectedVersionId)
 	if errors.Is(err, ErrorBadVersionId) {
 		return &proto.PutResponse{
@@ -324,6 +702,28 @@ func (d *db) applyPut(commitOffset int64, batch WriteBatch, notifications *notif
 		return nil, errors.Wrap(err, "oxia db: failed to apply batch")
 	} else {
 		// No version conflict
+		var previousValue []byte
+		var previousVersion *proto.Version
+		if putReq.ReturnPrevious && se != nil {
+			// Read the previous state before the entry gets updated
+			previousValue = se.Value
+			previousVersion = toVersion(se)
+		}
+
+		var resultValue []byte
+		if putReq.Operation != proto.PutOperation_SET {
+			if resultValue, err = applyPutOperation(putReq, se); err != nil {
+				return &proto.PutResponse{
+					Status: proto.Status_INVALID_VALUE,
+				}, nil
+			}
+
+			// Store the resulting value, without modifying the original request
+			putReq = pb.Clone(putReq).(*proto.PutRequest)
+			putReq.Value = resultValue
+			putReq.Operation = proto.PutOperation_SET
+		}
+
 		status, err := updateOperationCallback.OnPut(batch, putReq, se)
 		if err != nil {
 			return nil, err
@@ -334,6 +734,13 @@ func (d *db) applyPut(commitOffset int64, batch WriteBatch, notifications *notif
 			}, nil
 		}
 
+		var expirationTimestamp *uint64
+		if putReq.TtlMs != nil {
+			expirationTimestamp = pb.Uint64(timestamp + *putReq.TtlMs)
+		}
+
+		var existingExpirationTimestamp *uint64
+		var previousVersionId *int64
 		if se == nil {
 			se = &proto.StorageEntry{
 				VersionId:             commitOffset,
@@ -343,14 +750,22 @@ func (d *db) applyPut(commitOffset int64, batch WriteBatch, notifications *notif
 				ModificationTimestamp: timestamp,
 				SessionId:             putReq.SessionId,
 				ClientIdentity:        putReq.ClientIdentity,
+				ExpirationTimestamp:   expirationTimestamp,
 			}
 		} else {
+			existingExpirationTimestamp = se.ExpirationTimestamp
+			previousVersionId = pb.Int64(se.VersionId)
 			se.VersionId = commitOffset
 			se.ModificationsCount += 1
 			se.Value = putReq.Value
 			se.ModificationTimestamp = timestamp
 			se.SessionId = putReq.SessionId
 			se.ClientIdentity = putReq.ClientIdentity
+			se.ExpirationTimestamp = expirationTimestamp
+		}
+
+		if err = updateExpirationIndex(batch, putReq.Key, existingExpirationTimestamp, expirationTimestamp); err != nil {
+			return nil, err
 		}
 
 		ser, err := pb.Marshal(se)
@@ -363,25 +778,63 @@ func (d *db) applyPut(commitOffset int64, batch WriteBatch, notifications *notif
 		}
 
 		if notifications != nil {
-			notifications.Modified(putReq.Key, se.VersionId, se.ModificationsCount)
+			notifications.Modified(putReq.Key, se.VersionId, se.ModificationsCount, se.Value, previousVersionId)
 		}
 
-		version := &proto.Version{
-			VersionId:          se.VersionId,
-			ModificationsCount: se.ModificationsCount,
-			CreatedTimestamp:   se.CreationTimestamp,
-			ModifiedTimestamp:  se.ModificationTimestamp,
-			SessionId:          se.SessionId,
-			ClientIdentity:     se.ClientIdentity,
-		}
+		version := toVersion(se)
 
 		d.log.Debug().
 			Str("key", putReq.Key).
 			Interface("version", version).
 			Msg("Applied put operation")
 
-		return &proto.PutResponse{Version: version}, nil
+		return &proto.PutResponse{
+			Version:         version,
+			Key:             generatedKey,
+			Value:           resultValue,
+			PreviousValue:   previousValue,
+			PreviousVersion: previousVersion,
+		}, nil
+	}
+}
+
+// nextSequentialKey generates the key for a put with sequence key deltas, by
+// appending to the requested key the next value of each of the sequences.
+// The last values of the sequences are stored in an internal key, so that
+// they are kept for each key prefix even after the records are deleted.
+func (d *db) nextSequentialKey(commitOffset int64, batch WriteBatch, putReq *proto.PutRequest, timestamp uint64) (string, error) {
+	lastValuesKey := sequentialKeysPrefix + putReq.Key
+	lastValues := make([]uint64, len(putReq.SequenceKeyDelta))
+
+	se, err := GetStorageEntry(batch, lastValuesKey)
+	if err != nil && !errors.Is(err, ErrorKeyNotFound) {
+		return "", err
+	} else if err == nil {
+		for i, value := range strings.Split(string(se.Value), ",") {
+			if i == len(lastValues) {
+				break
+			}
+			if lastValues[i], err = strconv.ParseUint(value, 10, 64); err != nil {
+				return "", errors.Wrap(err, "oxia db: invalid sequential keys entry")
+			}
+		}
+	}
+
+	key := putReq.Key
+	values := make([]string, len(lastValues))
+	for i, delta := range putReq.SequenceKeyDelta {
+		lastValues[i] += delta
+		key += fmt.Sprintf("-%020d", lastValues[i])
+		values[i] = strconv.FormatUint(lastValues[i], 10)
+	}
+
+	if _, err = d.applyPut(commitOffset, batch, nil, &proto.PutRequest{
+		Key:   lastValuesKey,
+		Value: []byte(strings.Join(values, ",")),
+	}, timestamp, NoOpCallback); err != nil {
+		return "", err
 	}
+	return key, nil
 }
 
 func (d *db) applyDelete(batch WriteBatch, notifications *notifications, delReq *proto.DeleteRequest, updateOperationCallback UpdateOperationCallback) (*proto.DeleteResponse, error) {
@@ -399,29 +852,47 @@ func (d *db) applyDelete(batch WriteBatch, notifications *notifications, delReq
 			return nil, err
 		}
 
+		if err = updateExpirationIndex(batch, delReq.Key, se.ExpirationTimestamp, nil); err != nil {
+			return nil, err
+		}
+
 		if err = batch.Delete(delReq.Key); err != nil {
 			return &proto.DeleteResponse{}, err
 		}
 
 		if notifications != nil {
-			notifications.Deleted(delReq.Key)
+			notifications.Deleted(delReq.Key, pb.Int64(se.VersionId))
 		}
 
 		d.log.Debug().
 			Str("key", delReq.Key).
 			Msg("Applied delete operation")
-		return &proto.DeleteResponse{Status: proto.Status_OK}, nil
+
+		res := &proto.DeleteResponse{Status: proto.Status_OK}
+		if delReq.ReturnPrevious {
+			res.PreviousValue = se.Value
+			res.PreviousVersion = toVersion(se)
+		}
+		return res, nil
 	}
 }
 
 func (d *db) applyDeleteRange(batch WriteBatch, notifications *notifications, delReq *proto.DeleteRangeRequest, updateOperationCallback UpdateOperationCallback) (*proto.DeleteRangeResponse, error) {
 	if notifications != nil || updateOperationCallback != NoOpCallback {
 		it := batch.KeyRangeScan(delReq.StartInclusive, delReq.EndExclusive)
-		for it.Next() {
+		for ; it.Valid(); it.Next() {
 			if notifications != nil {
-				notifications.Deleted(it.Key())
+				se, err := GetStorageEntry(batch, it.Key())
+				if err != nil {
+					return nil,
+						errors.Wrap(multierr.Combine(err, it.Close()), "oxia db: failed to delete range")
+				}
+				notifications.Deleted(it.Key(), pb.Int64(se.VersionId))
 			}
-			err := updateOperationCallback.OnDelete(batch, it.Key())
+			err := multierr.Combine(
+				updateOperationCallback.OnDelete(batch, it.Key()),
+				deleteExpirationIndex(batch, it.Key()),
+			)
 			if err != nil {
 				return nil,
 					errors.Wrap(multierr.Combine(err, it.Close()), "oxia db: failed to delete range")
@@ -446,6 +917,10 @@ func (d *db) applyDeleteRange(batch WriteBatch, notifications *notifications, de
 }
 
 func applyGet(kv KV, getReq *proto.GetRequest) (*proto.GetResponse, error) {
+	if getReq.ComparisonType != proto.KeyComparisonType_EQUAL {
+		return applyGetWithComparison(kv, getReq)
+	}
+
 	value, closer, err := kv.Get(getReq.Key)
 
 	if errors.Is(err, ErrorKeyNotFound) {
@@ -468,18 +943,22 @@ func applyGet(kv KV, getReq *proto.GetRequest) (*proto.GetResponse, error) {
 	}
 
 	return &proto.GetResponse{
-		Value: resValue,
-		Version: &proto.Version{
-			VersionId:          se.VersionId,
-			ModificationsCount: se.ModificationsCount,
-			CreatedTimestamp:   se.CreationTimestamp,
-			ModifiedTimestamp:  se.ModificationTimestamp,
-			SessionId:          se.SessionId,
-			ClientIdentity:     se.ClientIdentity,
-		},
+		Value:   resValue,
+		Version: toVersion(se),
 	}, nil
 }
 
+func toVersion(se *proto.StorageEntry) *proto.Version {
+	return &proto.Version{
+		VersionId:          se.VersionId,
+		ModificationsCount: se.ModificationsCount,
+		CreatedTimestamp:   se.CreationTimestamp,
+		ModifiedTimestamp:  se.ModificationTimestamp,
+		SessionId:          se.SessionId,
+		ClientIdentity:     se.ClientIdentity,
+	}
+}
+
 func GetStorageEntry(batch WriteBatch, key string) (*proto.StorageEntry, error) {
 	value, closer, err := batch.Get(key)
 	if err != nil {
@@ -529,6 +1008,10 @@ func (d *db) ReadNextNotifications(ctx context.Context, startOffset int64) ([]*p
 	return d.notificationsTracker.ReadNextNotifications(ctx, startOffset)
 }
 
+func (d *db) CheckNotificationsOffset(startOffset int64) error {
+	return d.notificationsTracker.CheckOffset(startOffset)
+}
+
 type noopCallback struct{}
 
 func (_ *noopCallback) OnPut(WriteBatch, *proto.PutRequest, *proto.StorageEntry) (proto.Status, error) {
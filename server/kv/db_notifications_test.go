@@ -17,6 +17,7 @@ package kv
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
+	pb "google.golang.org/protobuf/proto"
 	"oxia/common"
 	"oxia/proto"
 	"testing"
@@ -30,7 +31,7 @@ func init() {
 func TestDB_Notifications(t *testing.T) {
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 1*time.Hour, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	t0 := now()
@@ -159,6 +160,28 @@ func TestDB_Notifications(t *testing.T) {
 	assert.Equal(t, proto.NotificationType_KEY_MODIFIED, n.Type)
 	assert.EqualValues(t, 4, *n.VersionId)
 
+	// A delete range triggers a notification for each of the deleted keys
+	t5 := now()
+	_, _ = db.ProcessWrite(&proto.WriteRequest{
+		DeleteRanges: []*proto.DeleteRangeRequest{{
+			StartInclusive: "c",
+			EndExclusive:   "x2",
+		}},
+	}, 5, t5, NoOpCallback)
+
+	notifications, err = db.ReadNextNotifications(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(notifications))
+
+	nb = notifications[0]
+	assert.EqualValues(t, 5, nb.Offset)
+	assert.Equal(t, 3, len(nb.Notifications))
+	for _, key := range []string{"c", "d", "x1"} {
+		n, found = nb.Notifications[key]
+		assert.True(t, found, key)
+		assert.Equal(t, proto.NotificationType_KEY_DELETED, n.Type)
+	}
+
 	assert.NoError(t, db.Close())
 	assert.NoError(t, factory.Close())
 }
@@ -166,7 +189,7 @@ func TestDB_Notifications(t *testing.T) {
 func TestDB_NotificationsCancelWait(t *testing.T) {
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 1*time.Hour, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	t0 := now()
@@ -202,3 +225,115 @@ func TestDB_NotificationsCancelWait(t *testing.T) {
 	assert.NoError(t, db.Close())
 	assert.NoError(t, factory.Close())
 }
+
+func TestDB_NotificationsValues(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 1*time.Hour, true, common.SystemClock)
+	assert.NoError(t, err)
+
+	largeValue := make([]byte, maxNotificationValueSize+1)
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "a", Value: []byte("0")},
+			{Key: "b", Value: largeValue},
+		},
+	}, 0, now(), NoOpCallback)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts:    []*proto.PutRequest{{Key: "a", Value: []byte("1")}},
+		Deletes: []*proto.DeleteRequest{{Key: "b"}},
+	}, 1, now(), NoOpCallback)
+	assert.NoError(t, err)
+
+	notifications, err := db.ReadNextNotifications(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(notifications))
+
+	n := notifications[0].Notifications["a"]
+	assert.Equal(t, proto.NotificationType_KEY_CREATED, n.Type)
+	assert.Equal(t, "0", string(n.Value))
+	assert.Nil(t, n.PreviousVersionId)
+
+	// The value exceeds the size limit
+	n = notifications[0].Notifications["b"]
+	assert.Equal(t, proto.NotificationType_KEY_CREATED, n.Type)
+	assert.Nil(t, n.Value)
+
+	n = notifications[1].Notifications["a"]
+	assert.Equal(t, proto.NotificationType_KEY_MODIFIED, n.Type)
+	assert.Equal(t, "1", string(n.Value))
+	assert.EqualValues(t, 1, *n.VersionId)
+	assert.EqualValues(t, 0, *n.PreviousVersionId)
+
+	n = notifications[1].Notifications["b"]
+	assert.Equal(t, proto.NotificationType_KEY_DELETED, n.Type)
+	assert.Nil(t, n.Value)
+	assert.EqualValues(t, 0, *n.PreviousVersionId)
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_NotificationsWithoutValues(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 1*time.Hour, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{{Key: "a", Value: []byte("0")}},
+	}, 0, now(), NoOpCallback)
+	assert.NoError(t, err)
+
+	notifications, err := db.ReadNextNotifications(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(notifications))
+
+	// The values are not stored, unless they are enabled
+	n := notifications[0].Notifications["a"]
+	assert.Equal(t, proto.NotificationType_KEY_CREATED, n.Type)
+	assert.EqualValues(t, 0, *n.VersionId)
+	assert.Nil(t, n.Value)
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_NotificationsSameOffset(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 1*time.Hour, true, common.SystemClock)
+	assert.NoError(t, err)
+
+	// The requests appended to the wal in the same entry share the offset
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{{Key: "a", Value: []byte("0")}},
+	}, 0, now(), NoOpCallback)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "a", Value: []byte("1"), ExpectedVersionId: pb.Int64(-1)},
+			{Key: "b", Value: []byte("2")},
+		},
+	}, 0, now(), NoOpCallback)
+	assert.NoError(t, err)
+
+	notifications, err := db.ReadNextNotifications(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(notifications))
+	assert.Equal(t, 2, len(notifications[0].Notifications))
+
+	n := notifications[0].Notifications["a"]
+	assert.Equal(t, proto.NotificationType_KEY_CREATED, n.Type)
+	assert.Equal(t, "0", string(n.Value))
+
+	n = notifications[0].Notifications["b"]
+	assert.Equal(t, proto.NotificationType_KEY_CREATED, n.Type)
+	assert.Equal(t, "2", string(n.Value))
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
@@ -15,18 +15,22 @@
 package kv
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	pb "google.golang.org/protobuf/proto"
+	"math"
 	"oxia/common"
 	"oxia/proto"
 	"oxia/server/wal"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestDBSimple(t *testing.T) {
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	req := &proto.WriteRequest{
@@ -166,7 +170,7 @@ func TestDBSimple(t *testing.T) {
 func TestDBSameKeyMutations(t *testing.T) {
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	writeReq := &proto.WriteRequest{
@@ -276,7 +280,7 @@ func TestDBSameKeyMutations(t *testing.T) {
 func TestDBList(t *testing.T) {
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	writeReq := &proto.WriteRequest{
@@ -346,10 +350,78 @@ func keyIteratorToSlice(it KeyIterator) []string {
 	return keys
 }
 
+func TestDBList_Paginated(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/a", Value: []byte("0")},
+			{Key: "/b", Value: []byte("1")},
+			{Key: "/b/c", Value: []byte("2")},
+			{Key: "/c", Value: []byte("3")},
+			{Key: "/d", Value: []byte("4")},
+		},
+	}, wal.InvalidOffset, now(), NoOpCallback)
+	assert.NoError(t, err)
+
+	keys := keyIteratorToSlice(db.List(&proto.ListRequest{
+		StartInclusive: "/a",
+		EndExclusive:   "/e",
+		Limit:          pb.Uint32(2),
+	}))
+	assert.Equal(t, []string{"/a", "/b"}, keys)
+
+	keys = keyIteratorToSlice(db.List(&proto.ListRequest{
+		StartInclusive: "/a",
+		EndExclusive:   "/e",
+		Limit:          pb.Uint32(2),
+		Continuation:   pb.String("/b"),
+	}))
+	assert.Equal(t, []string{"/c", "/d"}, keys)
+
+	keys = keyIteratorToSlice(db.List(&proto.ListRequest{
+		StartInclusive: "/a",
+		EndExclusive:   "/e",
+		Continuation:   pb.String("/d"),
+	}))
+	assert.Len(t, keys, 0)
+
+	keys = keyIteratorToSlice(db.List(&proto.ListRequest{
+		StartInclusive: "/a",
+		EndExclusive:   "/d",
+		Reverse:        true,
+	}))
+	assert.Equal(t, []string{"/c", "/b", "/a"}, keys)
+
+	keys = keyIteratorToSlice(db.List(&proto.ListRequest{
+		StartInclusive: "/a",
+		EndExclusive:   "/e",
+		Limit:          pb.Uint32(1),
+		Reverse:        true,
+		Continuation:   pb.String("/c"),
+	}))
+	assert.Equal(t, []string{"/b"}, keys)
+
+	// Keys with more path segments sort after all the others
+	keys = keyIteratorToSlice(db.List(&proto.ListRequest{
+		StartInclusive: "/",
+		EndExclusive:   "/b/~",
+		Reverse:        true,
+		Limit:          pb.Uint32(1),
+	}))
+	assert.Equal(t, []string{"/b/c"}, keys)
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
 func TestDBDeleteRange(t *testing.T) {
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	writeReq := &proto.WriteRequest{
@@ -416,7 +488,7 @@ func TestDB_ReadCommitOffset(t *testing.T) {
 
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	commitOffset, err := db.ReadCommitOffset()
@@ -443,7 +515,7 @@ func TestDB_ReadCommitOffset(t *testing.T) {
 func TestDb_UpdateTerm(t *testing.T) {
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	term, err := db.ReadTerm()
@@ -460,7 +532,7 @@ func TestDb_UpdateTerm(t *testing.T) {
 	assert.NoError(t, db.Close())
 
 	// Reopen and verify the term is maintained
-	db, err = NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err = NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	term, err = db.ReadTerm()
@@ -475,7 +547,7 @@ func TestDB_Delete(t *testing.T) {
 
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	writeReq := &proto.WriteRequest{
@@ -490,7 +562,7 @@ func TestDB_Delete(t *testing.T) {
 	assert.NoError(t, db.Delete())
 
 	// Reopen and verify the db is empty
-	db, err = NewDB(common.DefaultNamespace, 1, factory, 0, common.SystemClock)
+	db, err = NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	getRes, err := db.Get(&proto.GetRequest{
@@ -502,3 +574,665 @@ func TestDB_Delete(t *testing.T) {
 
 	assert.NoError(t, factory.Close())
 }
+
+func TestDB_Transaction(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	res, err := db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "a", Value: []byte("0")},
+			{Key: "b", Value: []byte("1")},
+		},
+		Transaction: true,
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(res.Puts))
+	assert.Equal(t, proto.Status_OK, res.Puts[0].Status)
+	assert.Equal(t, proto.Status_OK, res.Puts[1].Status)
+
+	res, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{ // Would succeed, but it's discarded with the rest of the transaction
+				Key:   "c",
+				Value: []byte("2"),
+			},
+			{ // Should fail: the key already exists
+				Key:               "a",
+				Value:             []byte("3"),
+				ExpectedVersionId: pb.Int64(-1),
+			},
+		},
+		Deletes: []*proto.DeleteRequest{
+			{ // Would succeed, but it's discarded with the rest of the transaction
+				Key: "b",
+			},
+		},
+		Transaction: true,
+	}, 1, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(res.Puts))
+	assert.Equal(t, proto.Status_TRANSACTION_ABORTED, res.Puts[0].Status)
+	assert.Nil(t, res.Puts[0].Version)
+	assert.Equal(t, proto.Status_UNEXPECTED_VERSION_ID, res.Puts[1].Status)
+	assert.Equal(t, 1, len(res.Deletes))
+	assert.Equal(t, proto.Status_TRANSACTION_ABORTED, res.Deletes[0].Status)
+
+	// None of the operations should have been applied
+	getRes, err := db.Get(&proto.GetRequest{Key: "a", IncludeValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Status_OK, getRes.Status)
+	assert.Equal(t, "0", string(getRes.Value))
+
+	getRes, err = db.Get(&proto.GetRequest{Key: "b", IncludeValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Status_OK, getRes.Status)
+
+	getRes, err = db.Get(&proto.GetRequest{Key: "c", IncludeValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Status_KEY_NOT_FOUND, getRes.Status)
+
+	// The commit offset is advanced anyway
+	commitOffset, err := db.ReadCommitOffset()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, commitOffset)
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_SequentialKeys(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	res, err := db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/q", Value: []byte("0"), SequenceKeyDelta: []uint64{1}},
+			{Key: "/q", Value: []byte("1"), SequenceKeyDelta: []uint64{1}},
+			{Key: "/r", Value: []byte("2"), SequenceKeyDelta: []uint64{5, 2}},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/q-00000000000000000001", res.Puts[0].GetKey())
+	assert.Equal(t, "/q-00000000000000000002", res.Puts[1].GetKey())
+	assert.Equal(t, "/r-00000000000000000005-00000000000000000002", res.Puts[2].GetKey())
+
+	// The sequences are maintained even if the records are deleted
+	res, err = db.ProcessWrite(&proto.WriteRequest{
+		Deletes: []*proto.DeleteRequest{{Key: "/q-00000000000000000002"}},
+		Puts: []*proto.PutRequest{
+			{Key: "/q", Value: []byte("3"), SequenceKeyDelta: []uint64{3}},
+			{Key: "/r", Value: []byte("4"), SequenceKeyDelta: []uint64{5, 2}},
+		},
+	}, 1, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/q-00000000000000000005", res.Puts[0].GetKey())
+	assert.Equal(t, "/r-00000000000000000010-00000000000000000004", res.Puts[1].GetKey())
+	assert.Equal(t, proto.Status_OK, res.Deletes[0].Status)
+
+	getRes, err := db.Get(&proto.GetRequest{Key: "/q-00000000000000000005", IncludeValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Status_OK, getRes.Status)
+	assert.Equal(t, "3", string(getRes.Value))
+
+	getRes, err = db.Get(&proto.GetRequest{Key: "/q", IncludeValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Status_KEY_NOT_FOUND, getRes.Status)
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_PutOperations(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	res, err := db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/counter", Value: []byte("5"), Operation: proto.PutOperation_ADD},
+			{Key: "/counter", Value: []byte("-2"), Operation: proto.PutOperation_ADD},
+			{Key: "/log", Value: []byte("a"), Operation: proto.PutOperation_APPEND},
+			{Key: "/log", Value: []byte("bc"), Operation: proto.PutOperation_APPEND},
+			{Key: "/text", Value: []byte("hello")},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	assert.Equal(t, proto.Status_OK, res.Puts[0].Status)
+	assert.Equal(t, "5", string(res.Puts[0].Value))
+	assert.Equal(t, "3", string(res.Puts[1].Value))
+	assert.EqualValues(t, 1, res.Puts[1].Version.ModificationsCount)
+	assert.Equal(t, "a", string(res.Puts[2].Value))
+	assert.Equal(t, "abc", string(res.Puts[3].Value))
+	assert.Nil(t, res.Puts[4].Value)
+
+	res, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			// The current value is not a number
+			{Key: "/text", Value: []byte("1"), Operation: proto.PutOperation_ADD},
+			// The delta is not a number
+			{Key: "/counter", Value: []byte("x"), Operation: proto.PutOperation_ADD},
+			// Overflow
+			{Key: "/counter", Value: []byte(strconv.FormatInt(math.MaxInt64, 10)), Operation: proto.PutOperation_ADD},
+			// Conditional operation
+			{Key: "/counter", Value: []byte("1"), Operation: proto.PutOperation_ADD, ExpectedVersionId: pb.Int64(0)},
+			{Key: "/counter", Value: []byte("1"), Operation: proto.PutOperation_ADD, ExpectedVersionId: pb.Int64(5)},
+		},
+	}, 1, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	assert.Equal(t, proto.Status_INVALID_VALUE, res.Puts[0].Status)
+	assert.Equal(t, proto.Status_INVALID_VALUE, res.Puts[1].Status)
+	assert.Equal(t, proto.Status_INVALID_VALUE, res.Puts[2].Status)
+	assert.Equal(t, proto.Status_OK, res.Puts[3].Status)
+	assert.Equal(t, "4", string(res.Puts[3].Value))
+	assert.Equal(t, proto.Status_UNEXPECTED_VERSION_ID, res.Puts[4].Status)
+
+	getRes, err := db.Get(&proto.GetRequest{Key: "/counter", IncludeValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "4", string(getRes.Value))
+
+	getRes, err = db.Get(&proto.GetRequest{Key: "/text", IncludeValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(getRes.Value))
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_ReturnPrevious(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	res, err := db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "a", Value: []byte("0"), ReturnPrevious: true},
+			{Key: "b", Value: []byte("1")},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	// The record did not exist
+	assert.Equal(t, proto.Status_OK, res.Puts[0].Status)
+	assert.Nil(t, res.Puts[0].PreviousValue)
+	assert.Nil(t, res.Puts[0].PreviousVersion)
+
+	res, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "a", Value: []byte("2"), ReturnPrevious: true},
+			{Key: "b", Value: []byte("3")},
+		},
+		Deletes: []*proto.DeleteRequest{
+			{Key: "a", ReturnPrevious: true},
+			{Key: "b"},
+			{Key: "c", ReturnPrevious: true},
+		},
+	}, 1, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	assert.Equal(t, proto.Status_OK, res.Puts[0].Status)
+	assert.Equal(t, "0", string(res.Puts[0].PreviousValue))
+	assert.EqualValues(t, 0, res.Puts[0].PreviousVersion.VersionId)
+	assert.EqualValues(t, 0, res.Puts[0].PreviousVersion.ModificationsCount)
+	assert.EqualValues(t, 1, res.Puts[0].Version.VersionId)
+	assert.Nil(t, res.Puts[1].PreviousValue)
+	assert.Nil(t, res.Puts[1].PreviousVersion)
+
+	assert.Equal(t, proto.Status_OK, res.Deletes[0].Status)
+	assert.Equal(t, "2", string(res.Deletes[0].PreviousValue))
+	assert.EqualValues(t, 1, res.Deletes[0].PreviousVersion.VersionId)
+	assert.EqualValues(t, 1, res.Deletes[0].PreviousVersion.ModificationsCount)
+	assert.Nil(t, res.Deletes[1].PreviousValue)
+	assert.Nil(t, res.Deletes[1].PreviousVersion)
+	assert.Equal(t, proto.Status_KEY_NOT_FOUND, res.Deletes[2].Status)
+	assert.Nil(t, res.Deletes[2].PreviousVersion)
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_ExpiredRecords(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/a", Value: []byte("0"), TtlMs: pb.Uint64(100)},
+			{Key: "/b", Value: []byte("1"), TtlMs: pb.Uint64(200)},
+			{Key: "/c", Value: []byte("2"), TtlMs: pb.Uint64(300)},
+			{Key: "/d", Value: []byte("3")},
+		},
+	}, 0, 1000, NoOpCallback)
+	assert.NoError(t, err)
+
+	deletes, orphans, err := db.ReadExpiredRecords(1099, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, deletes)
+	assert.Empty(t, orphans)
+
+	deletes, orphans, err = db.ReadExpiredRecords(1200, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(deletes))
+	assert.Equal(t, "/a", deletes[0].Key)
+	assert.EqualValues(t, 0, *deletes[0].ExpectedVersionId)
+	assert.Equal(t, "/b", deletes[1].Key)
+	assert.Empty(t, orphans)
+
+	deletes, _, err = db.ReadExpiredRecords(1200, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deletes))
+
+	// Overwriting a record replaces its ttl, deleting a record removes it
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/a", Value: []byte("4")},
+			{Key: "/c", Value: []byte("5"), TtlMs: pb.Uint64(1000)},
+		},
+		Deletes: []*proto.DeleteRequest{{Key: "/b"}},
+	}, 1, 1100, NoOpCallback)
+	assert.NoError(t, err)
+
+	deletes, orphans, err = db.ReadExpiredRecords(2000, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, deletes)
+	assert.Empty(t, orphans)
+
+	deletes, orphans, err = db.ReadExpiredRecords(2100, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deletes))
+	assert.Equal(t, "/c", deletes[0].Key)
+	assert.EqualValues(t, 1, *deletes[0].ExpectedVersionId)
+	assert.Empty(t, orphans)
+
+	// Deleting a range also removes the ttl of the records
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		DeleteRanges: []*proto.DeleteRangeRequest{{StartInclusive: "/a", EndExclusive: "/z"}},
+	}, 2, 1200, NoOpCallback)
+	assert.NoError(t, err)
+
+	deletes, orphans, err = db.ReadExpiredRecords(2100, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, deletes)
+	assert.Empty(t, orphans)
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_ExpiredRecordsOrphans(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	d, err := NewDB(common.DefaultNamespace, 1, factory, 1*time.Hour, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = d.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/a", Value: []byte("0"), TtlMs: pb.Uint64(100)},
+			{Key: "/b", Value: []byte("1"), TtlMs: pb.Uint64(500)},
+		},
+	}, 0, 1000, NoOpCallback)
+	assert.NoError(t, err)
+
+	// Index entries left behind for a deleted record, and for a record that
+	// now has a different expiration
+	wb := d.(*db).kv.NewWriteBatch()
+	assert.NoError(t, wb.Put(expirationIndexKey("/deleted", 1050), []byte{}))
+	assert.NoError(t, wb.Put(expirationIndexKey("/b", 1150), []byte{}))
+	assert.NoError(t, wb.Commit())
+	assert.NoError(t, wb.Close())
+
+	deletes, orphans, err := d.ReadExpiredRecords(1200, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deletes))
+	assert.Equal(t, "/a", deletes[0].Key)
+	assert.Equal(t, 2, len(orphans))
+	assert.Equal(t, expirationIndexKey("/deleted", 1050), orphans[0])
+	assert.Equal(t, expirationIndexKey("/b", 1150), orphans[1])
+
+	// The orphans count toward the max count
+	deletes, orphans, err = d.ReadExpiredRecords(1200, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deletes))
+	assert.Equal(t, 1, len(orphans))
+
+	deletes, orphans, err = d.ReadExpiredRecords(1200, 10)
+	assert.NoError(t, err)
+	_, err = d.ProcessWrite(&proto.WriteRequest{Deletes: deletes}, 1, 1200, NoOpCallback)
+	assert.NoError(t, err)
+	assert.NoError(t, d.DeleteOrphanedExpirationIndexKeys(orphans))
+
+	// Only the expired record is notified, not the internal keys
+	notifications, err := d.ReadNextNotifications(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(notifications))
+	assert.Equal(t, 1, len(notifications[0].Notifications))
+	assert.Equal(t, proto.NotificationType_KEY_DELETED, notifications[0].Notifications["/a"].Type)
+
+	deletes, orphans, err = d.ReadExpiredRecords(1200, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, deletes)
+	assert.Empty(t, orphans)
+
+	// An entry that is not orphaned anymore is kept
+	assert.NoError(t, d.DeleteOrphanedExpirationIndexKeys([]string{expirationIndexKey("/b", 1500)}))
+
+	// The record with a different expiration is still tracked
+	deletes, orphans, err = d.ReadExpiredRecords(1500, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deletes))
+	assert.Equal(t, "/b", deletes[0].Key)
+	assert.Empty(t, orphans)
+
+	assert.NoError(t, d.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_ListSkipsInternalKeys(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "a", Value: []byte("0")},
+			{Key: "/a", Value: []byte("1")},
+			{Key: common.InternalKeyPrefix + "x", Value: []byte("2")},
+			{Key: "zz/a", Value: []byte("3")},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	list := func(request *proto.ListRequest) []string {
+		it := db.List(request)
+		keys := make([]string, 0)
+		for ; it.Valid(); it.Next() {
+			keys = append(keys, it.Key())
+		}
+		assert.NoError(t, it.Close())
+		return keys
+	}
+
+	for _, test := range []struct {
+		request *proto.ListRequest
+		keys    []string
+	}{
+		{&proto.ListRequest{StartInclusive: "", EndExclusive: "\xff/"}, []string{"a", "/a", "zz/a"}},
+		{&proto.ListRequest{StartInclusive: "", EndExclusive: "\xff/", Reverse: true}, []string{"zz/a", "/a", "a"}},
+		{&proto.ListRequest{StartInclusive: "", EndExclusive: "\xff/", Limit: pb.Uint32(2)}, []string{"a", "/a"}},
+		{&proto.ListRequest{StartInclusive: "", EndExclusive: "\xff/", Continuation: pb.String("/a")}, []string{"zz/a"}},
+		{&proto.ListRequest{StartInclusive: "", EndExclusive: "\xff/", Reverse: true, Continuation: pb.String("zz/a")}, []string{"/a", "a"}},
+		{&proto.ListRequest{StartInclusive: common.InternalKeyPrefix, EndExclusive: common.InternalKeysEnd}, []string{}},
+		{&proto.ListRequest{StartInclusive: common.InternalKeyPrefix + "x", EndExclusive: "zz/b"}, []string{"zz/a"}},
+	} {
+		assert.Equal(t, test.keys, list(test.request), "%v", test.request)
+	}
+
+	// The internal listings include the internal keys
+	it := db.ListInternal(&proto.ListRequest{StartInclusive: common.InternalKeyPrefix + "x", EndExclusive: common.InternalKeyPrefix + "y"})
+	assert.True(t, it.Valid())
+	assert.Equal(t, common.InternalKeyPrefix+"x", it.Key())
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Close())
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_RangeScan(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/a", Value: []byte("0")},
+			{Key: "/b", Value: []byte("1")},
+			{Key: "/b/c", Value: []byte("2")},
+			{Key: "/c", Value: []byte("3")},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	it := db.RangeScan(&proto.RangeScanRequest{
+		StartInclusive: "/a",
+		EndExclusive:   "/c",
+	})
+
+	assert.True(t, it.Valid())
+	r, err := it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "/a", r.GetKey())
+	assert.Equal(t, "0", string(r.Value))
+	assert.EqualValues(t, 0, r.Version.VersionId)
+
+	assert.True(t, it.Next())
+	r, err = it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "/b", r.GetKey())
+	assert.Equal(t, "1", string(r.Value))
+
+	assert.False(t, it.Next())
+	assert.False(t, it.Valid())
+	assert.NoError(t, it.Close())
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_RangeScanSkipsInternalKeys(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/a", Value: []byte("0")},
+			{Key: common.InternalKeyPrefix + "x", Value: []byte("1")},
+			{Key: "zz/a", Value: []byte("2")},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		start string
+		end   string
+		keys  []string
+	}{
+		{"", "\xff/", []string{"/a", "zz/a"}},
+		{common.InternalKeyPrefix, common.InternalKeysEnd, []string{}},
+		{common.InternalKeyPrefix + "x", "zz/b", []string{"zz/a"}},
+		{"/a", common.InternalKeyPrefix + "y", []string{"/a"}},
+	} {
+		it := db.RangeScan(&proto.RangeScanRequest{
+			StartInclusive: test.start,
+			EndExclusive:   test.end,
+		})
+
+		keys := make([]string, 0)
+		for ; it.Valid(); it.Next() {
+			r, err := it.Value()
+			assert.NoError(t, err)
+			keys = append(keys, r.GetKey())
+		}
+		assert.NoError(t, it.Close())
+		assert.Equal(t, test.keys, keys, "[%q, %q)", test.start, test.end)
+	}
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_CountRange(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/a", Value: []byte("0")},
+			{Key: "/b", Value: []byte("12")},
+			{Key: "/b/c", Value: []byte("345")},
+			{Key: "/c", Value: []byte("6789")},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	res, err := db.CountRange(&proto.CountRangeRequest{
+		StartInclusive: "/a",
+		EndExclusive:   "/c",
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, res.Count)
+	assert.EqualValues(t, 3, res.TotalValueSize)
+
+	res, err = db.CountRange(&proto.CountRangeRequest{
+		StartInclusive: "/b/",
+		EndExclusive:   "/b//",
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, res.Count)
+	assert.EqualValues(t, 3, res.TotalValueSize)
+
+	res, err = db.CountRange(&proto.CountRangeRequest{
+		StartInclusive: "/x",
+		EndExclusive:   "/y",
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, res.Count)
+	assert.EqualValues(t, 0, res.TotalValueSize)
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_CountRangeSkipsInternalKeys(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "/a", Value: []byte("0")},
+			{Key: common.InternalKeyPrefix + "x", Value: []byte("12")},
+			{Key: "zz/a", Value: []byte("345")},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		start          string
+		end            string
+		count          int64
+		totalValueSize int64
+	}{
+		{"", "\xff/", 2, 4},
+		{common.InternalKeyPrefix, common.InternalKeysEnd, 0, 0},
+		{common.InternalKeyPrefix + "x", "zz/b", 1, 3},
+		{"/a", common.InternalKeyPrefix + "y", 1, 1},
+	} {
+		res, err := db.CountRange(&proto.CountRangeRequest{
+			StartInclusive: test.start,
+			EndExclusive:   test.end,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, test.count, res.Count, "[%q, %q)", test.start, test.end)
+		assert.Equal(t, test.totalValueSize, res.TotalValueSize, "[%q, %q)", test.start, test.end)
+	}
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
+
+func TestDB_GetWithComparison(t *testing.T) {
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	db, err := NewDB(common.DefaultNamespace, 1, factory, 0, false, common.SystemClock)
+	assert.NoError(t, err)
+
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{
+			{Key: "a", Value: []byte("0")},
+			{Key: "c", Value: []byte("1")},
+			{Key: "e", Value: []byte("2")},
+			{Key: "__oxia-like", Value: []byte("3")},
+		},
+	}, 0, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	// Make sure that the internal keys are present, and skipped by the lookups
+	_, err = db.ProcessWrite(&proto.WriteRequest{
+		Puts: []*proto.PutRequest{{Key: "z", Value: []byte("4")}},
+	}, 1, 0, NoOpCallback)
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		key            string
+		comparisonType proto.KeyComparisonType
+		expectedKey    string
+		expectedValue  string
+	}{
+		{"c", proto.KeyComparisonType_EQUAL, "c", "1"},
+		{"c", proto.KeyComparisonType_FLOOR, "c", "1"},
+		{"d", proto.KeyComparisonType_FLOOR, "c", "1"},
+		{"c", proto.KeyComparisonType_LOWER, "a", "0"},
+		{"a", proto.KeyComparisonType_LOWER, "__oxia-like", "3"},
+		{"c", proto.KeyComparisonType_CEILING, "c", "1"},
+		{"b", proto.KeyComparisonType_CEILING, "c", "1"},
+		{"c", proto.KeyComparisonType_HIGHER, "e", "2"},
+		{"y", proto.KeyComparisonType_HIGHER, "z", "4"},
+		{"zz", proto.KeyComparisonType_FLOOR, "z", "4"},
+		{"/a", proto.KeyComparisonType_FLOOR, "z", "4"},
+	} {
+		res, err := db.Get(&proto.GetRequest{
+			Key:            test.key,
+			IncludeValue:   true,
+			ComparisonType: test.comparisonType,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, proto.Status_OK, res.Status, test)
+		if test.comparisonType != proto.KeyComparisonType_EQUAL {
+			assert.Equal(t, test.expectedKey, res.GetKey(), test)
+		}
+		assert.Equal(t, test.expectedValue, string(res.Value), test)
+	}
+
+	for _, test := range []struct {
+		key            string
+		comparisonType proto.KeyComparisonType
+	}{
+		{"b", proto.KeyComparisonType_EQUAL},
+		{"__oxia-like", proto.KeyComparisonType_LOWER},
+		{"z", proto.KeyComparisonType_HIGHER},
+		{"/a", proto.KeyComparisonType_CEILING},
+	} {
+		res, err := db.Get(&proto.GetRequest{
+			Key:            test.key,
+			IncludeValue:   true,
+			ComparisonType: test.comparisonType,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, proto.Status_KEY_NOT_FOUND, res.Status, test)
+	}
+
+	assert.NoError(t, db.Close())
+	assert.NoError(t, factory.Close())
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"net/url"
+	"oxia/common"
+	"oxia/proto"
+)
+
+// The records with a ttl are tracked in an index, sorted by expiration time,
+// with one entry for each record: `__oxia/expiration/<timestamp>-<escaped-key>`
+const expirationIndexPrefix = common.InternalKeyPrefix + "expiration/"
+
+func expirationIndexKey(key string, expirationTimestamp uint64) string {
+	return fmt.Sprintf("%s%020d-%s", expirationIndexPrefix, expirationTimestamp, url.PathEscape(key))
+}
+
+func parseExpirationIndexKey(indexKey string) (key string, expirationTimestamp uint64, err error) {
+	var escapedKey string
+	if _, err := fmt.Sscanf(indexKey[len(expirationIndexPrefix):], "%020d-%s", &expirationTimestamp, &escapedKey); err != nil {
+		return "", 0, errors.Wrapf(err, "invalid expiration index key %s", indexKey)
+	}
+	key, err = url.PathUnescape(escapedKey)
+	return key, expirationTimestamp, err
+}
+
+// updateExpirationIndex replaces the index entry of the existing record, if any,
+// with the entry of the new one
+func updateExpirationIndex(batch WriteBatch, key string, existingExpirationTimestamp, expirationTimestamp *uint64) error {
+	if existingExpirationTimestamp != nil {
+		err := batch.Delete(expirationIndexKey(key, *existingExpirationTimestamp))
+		if err != nil && !errors.Is(err, ErrorKeyNotFound) {
+			return err
+		}
+	}
+
+	if expirationTimestamp != nil {
+		return batch.Put(expirationIndexKey(key, *expirationTimestamp), []byte{})
+	}
+	return nil
+}
+
+// deleteExpirationIndex removes the index entry of a record that is being deleted
+func deleteExpirationIndex(batch WriteBatch, key string) error {
+	se, err := GetStorageEntry(batch, key)
+	if errors.Is(err, ErrorKeyNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return updateExpirationIndex(batch, key, se.ExpirationTimestamp, nil)
+}
+
+// ReadExpiredRecords returns the delete requests for up to maxCount records
+// that have expired at the given timestamp.
+// The deletes are conditional on the current version of the records, so that
+// they will not have any effect if a record gets updated in the meantime.
+//
+// It also returns the orphaned index entries, whose record was already deleted
+// or has a different expiration, for them to be removed with
+// DeleteOrphanedExpirationIndexKeys instead of being scanned again on every
+// pass. The orphans count toward maxCount as well.
+func (d *db) ReadExpiredRecords(timestamp uint64, maxCount int) (deletes []*proto.DeleteRequest, orphans []string, err error) {
+	it := d.kv.KeyRangeScan(expirationIndexPrefix, fmt.Sprintf("%s%020d", expirationIndexPrefix, timestamp+1))
+	defer it.Close()
+
+	deletes = make([]*proto.DeleteRequest, 0)
+	orphans = make([]string, 0)
+	for ; it.Valid() && len(deletes)+len(orphans) < maxCount; it.Next() {
+		se, key, err := d.indexedRecord(it.Key())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if se == nil {
+			orphans = append(orphans, it.Key())
+			continue
+		}
+
+		deletes = append(deletes, &proto.DeleteRequest{
+			Key:               key,
+			ExpectedVersionId: &se.VersionId,
+		})
+	}
+
+	return deletes, orphans, nil
+}
+
+// DeleteOrphanedExpirationIndexKeys removes the index entries returned as
+// orphans by ReadExpiredRecords. They are internal keys, so they are deleted
+// directly, without notifications. The entries that are not orphaned anymore,
+// because their record was written again with the same expiration, are kept.
+func (d *db) DeleteOrphanedExpirationIndexKeys(indexKeys []string) error {
+	if len(indexKeys) == 0 {
+		return nil
+	}
+
+	batch := d.kv.NewWriteBatch()
+	for _, indexKey := range indexKeys {
+		se, _, err := d.indexedRecord(indexKey)
+		if err == nil && se == nil {
+			err = batch.Delete(indexKey)
+		}
+		if err != nil {
+			return multierr.Append(err, batch.Close())
+		}
+	}
+
+	return multierr.Append(batch.Commit(), batch.Close())
+}
+
+// indexedRecord returns the record of the index entry, or nil when the entry
+// is orphaned
+func (d *db) indexedRecord(indexKey string) (se *proto.StorageEntry, key string, err error) {
+	key, expirationTimestamp, err := parseExpirationIndexKey(indexKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	value, closer, err := d.kv.Get(key)
+	if errors.Is(err, ErrorKeyNotFound) {
+		return nil, key, nil
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	se, err = deserialize(value)
+	if err = multierr.Append(err, closer.Close()); err != nil {
+		return nil, "", err
+	}
+
+	if se.ExpirationTimestamp == nil || *se.ExpirationTimestamp != expirationTimestamp {
+		return nil, key, nil
+	}
+	return se, key, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"oxia/common"
+	"oxia/proto"
+)
+
+const (
+	// minKey sorts before any other key
+	minKey = ""
+
+	// maxKey sorts after any valid UTF-8 key: its first segment starts with a
+	// 0xff byte, which never appears in UTF-8 strings
+	maxKey = "\xff/"
+)
+
+// findKey returns the user key that is matching the requested key, according
+// to the comparison type
+func findKey(kv KV, key string, comparisonType proto.KeyComparisonType) (found string, ok bool, err error) {
+	switch comparisonType {
+	case proto.KeyComparisonType_FLOOR:
+		return lastKey(kv, minKey, key+"\x00")
+	case proto.KeyComparisonType_LOWER:
+		return lastKey(kv, minKey, key)
+	case proto.KeyComparisonType_CEILING:
+		return firstKey(kv, key, maxKey)
+	case proto.KeyComparisonType_HIGHER:
+		return firstKey(kv, key+"\x00", maxKey)
+	default:
+		return key, true, nil
+	}
+}
+
+func firstKey(kv KV, lower, upper string) (string, bool, error) {
+	for _, r := range common.UserKeyRanges(lower, upper) {
+		it := kv.KeyRangeScan(r.Start, r.End)
+		if it.Valid() {
+			key := it.Key()
+			return key, true, it.Close()
+		}
+		if err := it.Close(); err != nil {
+			return "", false, err
+		}
+	}
+	return "", false, nil
+}
+
+func lastKey(kv KV, lower, upper string) (string, bool, error) {
+	ranges := common.UserKeyRanges(lower, upper)
+	for i := len(ranges) - 1; i >= 0; i-- {
+		it := kv.KeyRangeScanReverse(ranges[i].Start, ranges[i].End)
+		if it.Valid() {
+			key := it.Key()
+			return key, true, it.Close()
+		}
+		if err := it.Close(); err != nil {
+			return "", false, err
+		}
+	}
+	return "", false, nil
+}
+
+func applyGetWithComparison(kv KV, getReq *proto.GetRequest) (*proto.GetResponse, error) {
+	key, ok, err := findKey(kv, getReq.Key, getReq.ComparisonType)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &proto.GetResponse{Status: proto.Status_KEY_NOT_FOUND}, nil
+	}
+
+	res, err := applyGet(kv, &proto.GetRequest{
+		Key:          key,
+		IncludeValue: getReq.IncludeValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Key = &key
+	return res, nil
+}
@@ -15,7 +15,6 @@
 package kv
 
 import (
-	"bytes"
 	"fmt"
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/vfs"
@@ -170,7 +169,7 @@ func newKVPebble(factory *PebbleFactory, namespace string, shardId int64) (KV, e
 	pbOptions := &pebble.Options{
 		Cache: factory.cache,
 		Comparer: &pebble.Comparer{
-			Compare:            CompareWithSlash,
+			Compare:            common.CompareWithSlash,
 			Equal:              pebble.DefaultComparer.Equal,
 			AbbreviatedKey:     pebble.DefaultComparer.AbbreviatedKey,
 			FormatKey:          pebble.DefaultComparer.FormatKey,
@@ -526,39 +525,6 @@ func (pl *PebbleLogger) Fatalf(format string, args ...interface{}) {
 	pl.zl.Fatal().Msgf(format, args...)
 }
 
-/// Custom comparator function
-
-func CompareWithSlash(a, b []byte) int {
-	for len(a) > 0 && len(b) > 0 {
-		idxA, idxB := bytes.IndexByte(a, '/'), bytes.IndexByte(b, '/')
-		if idxA < 0 && idxB < 0 {
-			return bytes.Compare(a, b)
-		} else if idxA < 0 && idxB >= 0 {
-			return -1
-		} else if idxA >= 0 && idxB < 0 {
-			return +1
-		}
-
-		// At this point, both slices have '/'
-		spanA, spanB := a[:idxA], b[:idxB]
-
-		spanRes := bytes.Compare(spanA, spanB)
-		if spanRes != 0 {
-			return spanRes
-		}
-
-		a, b = a[idxA+1:], b[idxB+1:]
-	}
-
-	if len(a) < len(b) {
-		return -1
-	} else if len(a) > len(b) {
-		return +1
-	} else {
-		return 0
-	}
-}
-
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 ///// Snapshots
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -33,6 +33,11 @@ import (
 const (
 	notificationsPrefix      = common.InternalKeyPrefix + "notifications"
 	maxNotificationBatchSize = 100
+
+	// The values are stored in the notifications only up to these sizes, to
+	// bound the space used by the notifications over the retention period
+	maxNotificationValueSize       = 64 << 10 //64Ki
+	maxNotificationBatchValuesSize = 1 << 20  //1Mi
 )
 
 var (
@@ -43,12 +48,15 @@ var (
 )
 
 type notifications struct {
-	batch proto.NotificationBatch
+	batch         proto.NotificationBatch
+	includeValues bool
+	valuesSize    int
 }
 
-func newNotifications(shardId int64, offset int64, timestamp uint64) *notifications {
+func newNotifications(shardId int64, offset int64, timestamp uint64, includeValues bool) *notifications {
 	return &notifications{
-		proto.NotificationBatch{
+		includeValues: includeValues,
+		batch: proto.NotificationBatch{
 			ShardId:       shardId,
 			Offset:        offset,
 			Timestamp:     timestamp,
@@ -57,21 +65,54 @@ func newNotifications(shardId int64, offset int64, timestamp uint64) *notificati
 	}
 }
 
-func (n *notifications) Modified(key string, versionId, modificationsCount int64) {
+func (n *notifications) Modified(key string, versionId, modificationsCount int64, value []byte, previousVersionId *int64) {
 	nType := proto.NotificationType_KEY_CREATED
 	if modificationsCount > 0 {
 		nType = proto.NotificationType_KEY_MODIFIED
 	}
 	n.batch.Notifications[key] = &proto.Notification{
-		Type:      nType,
-		VersionId: &versionId,
+		Type:              nType,
+		VersionId:         &versionId,
+		Value:             n.boundedValue(value),
+		PreviousVersionId: previousVersionId,
+	}
+}
+
+// merge adds the stored notifications of the same offset, before the ones of
+// the current request
+func (n *notifications) merge(value []byte) error {
+	stored := &proto.NotificationBatch{}
+	if err := pb.Unmarshal(value, stored); err != nil {
+		return errors.Wrap(err, "failed to deserialize notification batch")
+	}
+
+	for key, notification := range stored.Notifications {
+		n.batch.Notifications[key] = notification
+		n.valuesSize += len(notification.Value)
 	}
+	return nil
 }
 
-func (n *notifications) Deleted(key string) {
+func (n *notifications) Deleted(key string, previousVersionId *int64) {
 	n.batch.Notifications[key] = &proto.Notification{
-		Type: proto.NotificationType_KEY_DELETED,
+		Type:              proto.NotificationType_KEY_DELETED,
+		PreviousVersionId: previousVersionId,
+	}
+}
+
+// boundedValue returns the value to store in the notification, or nil if the
+// values are not stored or if it would exceed the size limits
+func (n *notifications) boundedValue(value []byte) []byte {
+	if !n.includeValues || len(value) > maxNotificationValueSize || n.valuesSize+len(value) > maxNotificationBatchValuesSize {
+		return nil
 	}
+
+	n.valuesSize += len(value)
+	if value == nil {
+		// Distinguish an empty value from a missing one
+		return []byte{}
+	}
+	return value
 }
 
 func notificationKey(offset int64) string {
@@ -189,6 +230,25 @@ func (nt *notificationsTracker) ReadNextNotifications(ctx context.Context, start
 	return res, nil
 }
 
+// CheckOffset verifies that the notifications starting from the given offset
+// are still available, without waiting for them to be committed
+func (nt *notificationsTracker) CheckOffset(startOffset int64) error {
+	if startOffset > nt.lastOffset.Load() {
+		// The notifications will be available once the entries are committed
+		return nil
+	}
+
+	it := nt.kv.KeyRangeScan(notificationKey(startOffset), lastNotificationKey)
+	defer it.Close()
+
+	// Every committed offset has a notification batch, unless it was
+	// already removed by the trimmer
+	if !it.Valid() || it.Key() != notificationKey(startOffset) {
+		return common.ErrorNotificationsTrimmed
+	}
+	return nil
+}
+
 func (nt *notificationsTracker) Close() error {
 	nt.cancel()
 	nt.closed.Store(true)
@@ -29,7 +29,7 @@ func TestNotificationsTrimmer(t *testing.T) {
 
 	factory, err := NewPebbleKVFactory(testKVOptions)
 	assert.NoError(t, err)
-	dbx, err := NewDB(common.DefaultNamespace, 1, factory, 10*time.Millisecond, clock)
+	dbx, err := NewDB(common.DefaultNamespace, 1, factory, 10*time.Millisecond, false, clock)
 	assert.NoError(t, err)
 	defer dbx.Close()
 
@@ -83,3 +83,39 @@ func firstNotification(t *testing.T, db DB) int64 {
 
 	return nextNotifications[0].Offset
 }
+
+func TestNotificationsTrimmer_CheckOffset(t *testing.T) {
+	clock := &common.MockedClock{}
+
+	factory, err := NewPebbleKVFactory(testKVOptions)
+	assert.NoError(t, err)
+	dbx, err := NewDB(common.DefaultNamespace, 1, factory, 10*time.Millisecond, false, clock)
+	assert.NoError(t, err)
+	defer dbx.Close()
+
+	for i := int64(0); i < 10; i++ {
+		_, err = dbx.ProcessWrite(&proto.WriteRequest{
+			Puts: []*proto.PutRequest{{
+				Key:   fmt.Sprintf("key-%d", i),
+				Value: []byte("0"),
+			}},
+		}, i, uint64(i), NoOpCallback)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, dbx.CheckNotificationsOffset(0))
+	assert.NoError(t, dbx.CheckNotificationsOffset(9))
+	// Not committed yet
+	assert.NoError(t, dbx.CheckNotificationsOffset(10))
+
+	clock.Set(15)
+
+	assert.Eventually(t, func() bool {
+		return firstNotification(t, dbx) == 6
+	}, 10*time.Second, 100*time.Millisecond)
+
+	assert.ErrorIs(t, dbx.CheckNotificationsOffset(0), common.ErrorNotificationsTrimmed)
+	assert.ErrorIs(t, dbx.CheckNotificationsOffset(5), common.ErrorNotificationsTrimmed)
+	assert.NoError(t, dbx.CheckNotificationsOffset(6))
+	assert.NoError(t, dbx.CheckNotificationsOffset(10))
+}
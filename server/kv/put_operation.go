@@ -0,0 +1,65 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"github.com/pkg/errors"
+	"math"
+	"oxia/proto"
+	"strconv"
+)
+
+var ErrorInvalidValue = errors.New("oxia: invalid value for the put operation")
+
+// applyPutOperation computes the new value of a record, by combining the value
+// of the request with the current entry, which is nil if the record does not
+// exist
+func applyPutOperation(putReq *proto.PutRequest, se *proto.StorageEntry) ([]byte, error) {
+	var current []byte
+	if se != nil {
+		current = se.Value
+	}
+
+	switch putReq.Operation {
+	case proto.PutOperation_ADD:
+		return addInt64(current, putReq.Value)
+	case proto.PutOperation_APPEND:
+		value := make([]byte, 0, len(current)+len(putReq.Value))
+		value = append(value, current...)
+		return append(value, putReq.Value...), nil
+	default:
+		return putReq.Value, nil
+	}
+}
+
+func addInt64(current []byte, delta []byte) ([]byte, error) {
+	d, err := strconv.ParseInt(string(delta), 10, 64)
+	if err != nil {
+		return nil, ErrorInvalidValue
+	}
+
+	var c int64
+	if len(current) > 0 {
+		if c, err = strconv.ParseInt(string(current), 10, 64); err != nil {
+			return nil, ErrorInvalidValue
+		}
+	}
+
+	if (d > 0 && c > math.MaxInt64-d) || (d < 0 && c < math.MinInt64-d) {
+		// The result would overflow
+		return nil, ErrorInvalidValue
+	}
+	return []byte(strconv.FormatInt(c+d, 10)), nil
+}
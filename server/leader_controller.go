@@ -44,6 +44,8 @@ type LeaderController interface {
 	Read(ctx context.Context, request *proto.ReadRequest) <-chan GetResult
 	List(ctx context.Context, request *proto.ListRequest) (<-chan string, error)
 	ListSliceNoMutex(ctx context.Context, request *proto.ListRequest) ([]string, error)
+	RangeScan(ctx context.Context, request *proto.RangeScanRequest) (<-chan GetResult, error)
+	CountRange(ctx context.Context, request *proto.CountRangeRequest) (*proto.CountRangeResponse, error)
 
 	// NewTerm Handle new term requests
 	NewTerm(req *proto.NewTermRequest) (*proto.NewTermResponse, error)
@@ -58,6 +60,12 @@ type LeaderController interface {
 	GetStatus(request *proto.GetStatusRequest) (*proto.GetStatusResponse, error)
 	DeleteShard(request *proto.DeleteShardRequest) (*proto.DeleteShardResponse, error)
 
+	// Namespace The namespace of the shard
+	Namespace() string
+
+	// ShardId The id of the shard
+	ShardId() int64
+
 	// Term The current term of the leader
 	Term() int64
 
@@ -92,6 +100,7 @@ type leaderController struct {
 	db              kv.DB
 	rpcClient       ReplicationRpcProvider
 	sessionManager  SessionManager
+	expirer         *expirer
 	walWriteBatcher batch.Batcher
 	log             zerolog.Logger
 
@@ -150,7 +159,7 @@ func NewLeaderController(config Config, namespace string, shardId int64, rpcClie
 	lc.walTrimmer = wal.NewTrimmer(namespace, shardId, lc.wal, config.WalRetentionTime, wal.DefaultCheckInterval,
 		common.SystemClock, lc)
 
-	if lc.db, err = kv.NewDB(namespace, shardId, kvFactory, config.NotificationsRetentionTime, common.SystemClock); err != nil {
+	if lc.db, err = kv.NewDB(namespace, shardId, kvFactory, config.NotificationsRetentionTime, config.NotificationsIncludeValues, common.SystemClock); err != nil {
 		return nil, err
 	}
 
@@ -182,6 +191,14 @@ func (lc *leaderController) Status() proto.ServingStatus {
 	return lc.status
 }
 
+func (lc *leaderController) Namespace() string {
+	return lc.namespace
+}
+
+func (lc *leaderController) ShardId() int64 {
+	return lc.shardId
+}
+
 func (lc *leaderController) Term() int64 {
 	lc.RLock()
 	defer lc.RUnlock()
@@ -270,6 +287,13 @@ func (lc *leaderController) NewTerm(req *proto.NewTermRequest) (*proto.NewTermRe
 		return nil, err
 	}
 
+	if lc.expirer != nil {
+		if err := lc.expirer.Close(); err != nil {
+			return nil, err
+		}
+		lc.expirer = nil
+	}
+
 	lc.log.Info().
 		Interface("last-entry", headEntryId).
 		Msg("Leader successfully initialized in new term")
@@ -357,6 +381,8 @@ func (lc *leaderController) BecomeLeader(req *proto.BecomeLeaderRequest) (*proto
 		return nil, err
 	}
 
+	lc.expirer = newExpirer(lc.namespace, lc.shardId, lc, expirationCheckInterval, common.SystemClock)
+
 	lc.log.Info().
 		Int64("term", lc.term).
 		Int64("head-offset", lc.leaderElectionHeadEntryId.Offset).
@@ -471,6 +497,9 @@ func (lc *leaderController) applyAllEntriesIntoDB() error {
 				return err
 			}
 		}
+		if err = lc.db.DeleteOrphanedExpirationIndexKeys(logEntryValue.GetRequests().OrphanedExpirationIndexKeys); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -605,12 +634,12 @@ func (lc *leaderController) List(ctx context.Context, request *proto.ListRequest
 		return nil, err
 	}
 
-	go lc.list(ctx, request, ch)
+	go lc.list(ctx, lc.db.List(request), ch)
 
 	return ch, nil
 }
 
-func (lc *leaderController) list(ctx context.Context, request *proto.ListRequest, ch chan<- string) {
+func (lc *leaderController) list(ctx context.Context, it kv.KeyIterator, ch chan<- string) {
 	common.DoWithLabels(map[string]string{
 		"oxia":  "list",
 		"shard": fmt.Sprintf("%d", lc.shardId),
@@ -619,7 +648,6 @@ func (lc *leaderController) list(ctx context.Context, request *proto.ListRequest
 		lc.log.Debug().
 			Msg("Received list request")
 
-		it := lc.db.List(request)
 		defer func() {
 			_ = it.Close()
 		}()
@@ -634,9 +662,75 @@ func (lc *leaderController) list(ctx context.Context, request *proto.ListRequest
 	})
 }
 
+func (lc *leaderController) RangeScan(ctx context.Context, request *proto.RangeScanRequest) (<-chan GetResult, error) {
+	ch := make(chan GetResult)
+
+	lc.RLock()
+	err := checkStatus(proto.ServingStatus_LEADER, lc.status)
+	lc.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	go lc.rangeScan(ctx, request, ch)
+
+	return ch, nil
+}
+
+func (lc *leaderController) rangeScan(ctx context.Context, request *proto.RangeScanRequest, ch chan<- GetResult) {
+	common.DoWithLabels(map[string]string{
+		"oxia":  "range-scan",
+		"shard": fmt.Sprintf("%d", lc.shardId),
+		"peer":  common.GetPeer(ctx),
+	}, func() {
+		lc.log.Debug().
+			Msg("Received range scan request")
+
+		it := lc.db.RangeScan(request)
+		defer func() {
+			_ = it.Close()
+		}()
+
+		for ; it.Valid(); it.Next() {
+			response, err := it.Value()
+			if err != nil {
+				ch <- GetResult{Err: err}
+				break
+			}
+			ch <- GetResult{Response: response}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		close(ch)
+	})
+}
+
+func (lc *leaderController) CountRange(ctx context.Context, request *proto.CountRangeRequest) (response *proto.CountRangeResponse, err error) {
+	lc.RLock()
+	err = checkStatus(proto.ServingStatus_LEADER, lc.status)
+	lc.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	common.DoWithLabels(map[string]string{
+		"oxia":  "count-range",
+		"shard": fmt.Sprintf("%d", lc.shardId),
+		"peer":  common.GetPeer(ctx),
+	}, func() {
+		lc.log.Debug().
+			Msg("Received count range request")
+
+		response, err = lc.db.CountRange(request)
+	})
+	return response, err
+}
+
 func (lc *leaderController) ListSliceNoMutex(ctx context.Context, request *proto.ListRequest) ([]string, error) {
 	ch := make(chan string)
-	go lc.list(ctx, request, ch)
+	// The internal keys are included, since they hold the state of the shard
+	go lc.list(ctx, lc.db.ListInternal(request), ch)
 	keys := make([]string, 0)
 	for {
 		select {
@@ -658,31 +752,63 @@ func (lc *leaderController) ListSliceNoMutex(ctx context.Context, request *proto
 // if that value has not previously been written. The leader adds
 // the entry to its log, updates its head offset.
 func (lc *leaderController) Write(request *proto.WriteRequest) (*proto.WriteResponse, error) {
-	_, resp, err := lc.write(func(_ int64) *proto.WriteRequest {
-		return request
-	}, false)
-	return resp, err
+	if err := checkSequentialKeys(request); err != nil {
+		return nil, err
+	}
+
+	return rejectInternalKeys(request).apply(request, func(request *proto.WriteRequest) (*proto.WriteResponse, error) {
+		splits := clipInternalKeys(request)
+		_, resp, err := lc.write(func(_ int64) *proto.WriteRequest {
+			return request
+		}, false)
+		if err != nil {
+			return nil, err
+		}
+		return mergeDeleteRanges(resp, splits), nil
+	})
 }
 
 func (lc *leaderController) write(request func(int64) *proto.WriteRequest, flush bool) (int64, *proto.WriteResponse, error) {
+	return lc.writeTask(NewWriteTask(request, flush))
+}
+
+func (lc *leaderController) writeTask(task *writeTask) (int64, *proto.WriteResponse, error) {
 	timer := lc.writeLatencyHisto.Timer()
 	defer timer.Done()
 
 	lc.log.Debug().
 		Msg("Write operation")
 
-	actualRequest, newOffset, timestamp, err := lc.appendToWal(request, flush)
+	actualRequest, newOffset, timestamp, err := lc.appendToWal(task)
 	if err != nil {
 		return wal.InvalidOffset, nil, err
 	}
 
 	resp, err := lc.quorumAckTracker.WaitForCommitOffset(newOffset, func() (*proto.WriteResponse, error) {
-		return lc.db.ProcessWrite(actualRequest, newOffset, timestamp, SessionUpdateOperationCallback)
+		resp, err := lc.db.ProcessWrite(actualRequest, newOffset, timestamp, SessionUpdateOperationCallback)
+		if err != nil {
+			return nil, err
+		}
+		return resp, lc.db.DeleteOrphanedExpirationIndexKeys(task.orphanedExpirationIndexKeys)
 	})
 	return newOffset, resp, err
 }
 
-func (lc *leaderController) appendToWal(request func(int64) *proto.WriteRequest, flush bool) (actualRequest *proto.WriteRequest, offset int64, timestamp uint64, err error) {
+// checkSequentialKeys rejects the invalid puts with sequence key deltas before
+// they get appended to the wal, since they could not be applied on any replica
+func checkSequentialKeys(request *proto.WriteRequest) error {
+	for _, put := range request.Puts {
+		if len(put.SequenceKeyDelta) == 0 {
+			continue
+		}
+		if put.ExpectedVersionId != nil || put.SequenceKeyDelta[0] == 0 {
+			return common.ErrorInvalidSequentialKeys
+		}
+	}
+	return nil
+}
+
+func (lc *leaderController) appendToWal(task *writeTask) (actualRequest *proto.WriteRequest, offset int64, timestamp uint64, err error) {
 	lc.Lock()
 
 	if err := checkStatus(proto.ServingStatus_LEADER, lc.status); err != nil {
@@ -690,7 +816,6 @@ func (lc *leaderController) appendToWal(request func(int64) *proto.WriteRequest,
 		return nil, wal.InvalidOffset, 0, err
 	}
 
-	task := NewWriteTask(request, flush)
 	lc.walWriteBatcher.Add(task)
 
 	lc.Unlock()
@@ -702,6 +827,7 @@ func (lc *leaderController) appendToWal(request func(int64) *proto.WriteRequest,
 func (lc *leaderController) GetNotifications(req *proto.NotificationsRequest, stream proto.OxiaClient_GetNotificationsServer) error {
 	// Create a context for handling this stream
 	ctx, cancel := context.WithCancel(stream.Context())
+	errCh := make(chan error, 1)
 
 	go common.DoWithLabels(map[string]string{
 		"oxia":  "dispatch-notifications",
@@ -713,10 +839,15 @@ func (lc *leaderController) GetNotifications(req *proto.NotificationsRequest, st
 				Str("peer", common.GetPeer(stream.Context())).
 				Msg("Failed to dispatch notifications")
 			cancel()
+			errCh <- err
 		}
 	})
 
 	select {
+	case err := <-errCh:
+		// Report the failure to the client
+		return err
+
 	case <-lc.ctx.Done():
 		// Leader is getting closed
 		cancel()
@@ -732,11 +863,18 @@ func (lc *leaderController) GetNotifications(req *proto.NotificationsRequest, st
 func (lc *leaderController) dispatchNotifications(ctx context.Context, req *proto.NotificationsRequest, stream proto.OxiaClient_GetNotificationsServer) error {
 	lc.log.Debug().
 		Interface("start-offset-exclusive", req.StartOffsetExclusive).
+		Strs("key-prefixes", req.KeyPrefixes).
+		Interface("key-min-inclusive", req.KeyMinInclusive).
+		Interface("key-max-exclusive", req.KeyMaxExclusive).
 		Msg("Dispatch notifications")
 
 	var offsetInclusive int64
 	if req.StartOffsetExclusive != nil {
 		offsetInclusive = *req.StartOffsetExclusive + 1
+
+		if err := lc.db.CheckNotificationsOffset(offsetInclusive); err != nil {
+			return err
+		}
 	} else {
 		commitOffset := lc.quorumAckTracker.CommitOffset()
 
@@ -758,6 +896,8 @@ func (lc *leaderController) dispatchNotifications(ctx context.Context, req *prot
 		offsetInclusive = commitOffset + 1
 	}
 
+	filter := newNotificationsFilter(req)
+
 	for ctx.Err() == nil {
 		notifications, err := lc.db.ReadNextNotifications(ctx, offsetInclusive)
 		if err != nil {
@@ -768,8 +908,35 @@ func (lc *leaderController) dispatchNotifications(ctx context.Context, req *prot
 			Int("list-size", len(notifications)).
 			Msg("Got a new list of notification batches")
 
+		if len(notifications) > 0 && notifications[0].Offset != offsetInclusive {
+			// The client was too slow and the notifications were trimmed
+			return common.ErrorNotificationsTrimmed
+		}
+
+		var skipped *proto.NotificationBatch
 		for _, n := range notifications {
-			if err := stream.Send(n); err != nil {
+			// Batches without any notification the client is interested in
+			// are not sent at all
+			filtered := filter.apply(n)
+			if filtered == nil {
+				skipped = n
+				continue
+			}
+			skipped = nil
+			if err := stream.Send(filtered); err != nil {
+				return err
+			}
+		}
+
+		if skipped != nil {
+			// An empty batch lets the client move its cursor past the batches
+			// that were filtered out, so that it will not resume from an offset
+			// that could be trimmed in the meantime
+			if err := stream.Send(&proto.NotificationBatch{
+				ShardId:   skipped.ShardId,
+				Offset:    skipped.Offset,
+				Timestamp: skipped.Timestamp,
+			}); err != nil {
 				return err
 			}
 		}
@@ -816,6 +983,11 @@ func (lc *leaderController) close() error {
 	}
 	lc.followerAckOffsetGauges = map[string]metrics.Gauge{}
 
+	if lc.expirer != nil {
+		err = multierr.Append(err, lc.expirer.Close())
+		lc.expirer = nil
+	}
+
 	err = multierr.Combine(err,
 		lc.sessionManager.Close(),
 		lc.walTrimmer.Close(),
@@ -390,7 +390,7 @@ func TestLeaderController_FenceTerm(t *testing.T) {
 		LogDir: t.TempDir(),
 	})
 
-	db, err := kv.NewDB(common.DefaultNamespace, shard, kvFactory, 1*time.Hour, common.SystemClock)
+	db, err := kv.NewDB(common.DefaultNamespace, shard, kvFactory, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 	// Force a new term in the DB before opening
 	assert.NoError(t, db.UpdateTerm(5))
@@ -437,7 +437,7 @@ func TestLeaderController_BecomeLeaderTerm(t *testing.T) {
 		LogDir: t.TempDir(),
 	})
 
-	db, err := kv.NewDB(common.DefaultNamespace, shard, kvFactory, 1*time.Hour, common.SystemClock)
+	db, err := kv.NewDB(common.DefaultNamespace, shard, kvFactory, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 	// Force a new term in the DB before opening
 	assert.NoError(t, db.UpdateTerm(5))
@@ -561,7 +561,7 @@ func TestLeaderController_AddFollower_Truncate(t *testing.T) {
 	// Prepare some data in the leader log & db
 	wal, err := walFactory.NewWal(common.DefaultNamespace, shard)
 	assert.NoError(t, err)
-	db, err := kv.NewDB(common.DefaultNamespace, shard, kvFactory, 1*time.Hour, common.SystemClock)
+	db, err := kv.NewDB(common.DefaultNamespace, shard, kvFactory, 1*time.Hour, false, common.SystemClock)
 	assert.NoError(t, err)
 
 	for i := int64(0); i < 10; i++ {
@@ -852,6 +852,58 @@ func TestLeaderController_Notifications(t *testing.T) {
 	assert.NoError(t, walFactory.Close())
 }
 
+func TestLeaderController_NotificationsFiltered(t *testing.T) {
+	var shard int64 = 1
+
+	kvFactory, _ := kv.NewPebbleKVFactory(testKVOptions)
+	walFactory := wal.NewInMemoryWalFactory()
+
+	lc, _ := NewLeaderController(Config{}, common.DefaultNamespace, shard, newMockRpcClient(), walFactory, kvFactory)
+	_, _ = lc.NewTerm(&proto.NewTermRequest{ShardId: shard, Term: 1})
+	_, _ = lc.BecomeLeader(&proto.BecomeLeaderRequest{
+		ShardId:           shard,
+		Term:              1,
+		ReplicationFactor: 1,
+		FollowerMaps:      nil,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newMockGetNotificationsServer(ctx)
+
+	go func() {
+		_ = lc.GetNotifications(&proto.NotificationsRequest{
+			ShardId:              shard,
+			StartOffsetExclusive: &wal.InvalidOffset,
+			KeyPrefixes:          []string{"/x/"},
+		}, stream)
+	}()
+
+	_, _ = lc.Write(&proto.WriteRequest{
+		ShardId: &shard,
+		Puts:    []*proto.PutRequest{{Key: "/y/a", Value: []byte("value-a")}},
+	})
+
+	// The batch is filtered out, but the client is still told about its offset
+	nb := <-stream.ch
+	assert.EqualValues(t, 0, nb.Offset)
+	assert.Empty(t, nb.Notifications)
+
+	_, _ = lc.Write(&proto.WriteRequest{
+		ShardId: &shard,
+		Puts:    []*proto.PutRequest{{Key: "/x/a", Value: []byte("value-a")}},
+	})
+
+	nb = <-stream.ch
+	assert.EqualValues(t, 1, nb.Offset)
+	assert.Equal(t, 1, len(nb.Notifications))
+	assert.NotNil(t, nb.Notifications["/x/a"])
+
+	cancel()
+	assert.NoError(t, lc.Close())
+	assert.NoError(t, kvFactory.Close())
+	assert.NoError(t, walFactory.Close())
+}
+
 func TestLeaderController_NotificationsCloseLeader(t *testing.T) {
 	var shard int64 = 1
 
@@ -944,6 +996,99 @@ func TestLeaderController_List(t *testing.T) {
 	assert.Len(t, list, 0)
 }
 
+func TestLeaderController_InternalKeys(t *testing.T) {
+	var shard int64 = 1
+
+	kvFactory, _ := kv.NewPebbleKVFactory(testKVOptions)
+	walFactory := wal.NewInMemoryWalFactory()
+
+	lc, _ := NewLeaderController(Config{}, common.DefaultNamespace, shard, newMockRpcClient(), walFactory, kvFactory)
+	_, _ = lc.NewTerm(&proto.NewTermRequest{ShardId: shard, Term: 1})
+	_, _ = lc.BecomeLeader(&proto.BecomeLeaderRequest{
+		ShardId:           shard,
+		Term:              1,
+		ReplicationFactor: 1,
+		FollowerMaps:      nil,
+	})
+
+	// The user keys sort before and after the internal ones
+	_, err := lc.Write(&proto.WriteRequest{
+		ShardId: &shard,
+		Puts: []*proto.PutRequest{
+			{Key: "a", Value: []byte{0}},
+			{Key: "/a", Value: []byte{0}},
+			{Key: "b/c", Value: []byte{0}},
+		},
+	})
+	assert.NoError(t, err)
+
+	// The operations on the internal keys are rejected, without failing the
+	// other operations of the request
+	res, err := lc.Write(&proto.WriteRequest{
+		ShardId: &shard,
+		Puts: []*proto.PutRequest{
+			{Key: common.InternalKeyPrefix + "term", Value: []byte("5")},
+			{Key: "c", Value: []byte{0}},
+		},
+		Deletes:      []*proto.DeleteRequest{{Key: common.InternalKeyPrefix + "commit-offset"}},
+		DeleteRanges: []*proto.DeleteRangeRequest{{StartInclusive: common.InternalKeyPrefix, EndExclusive: common.InternalKeysEnd}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Status_INVALID_KEY, res.Puts[0].Status)
+	assert.Equal(t, proto.Status_OK, res.Puts[1].Status)
+	assert.Equal(t, proto.Status_INVALID_KEY, res.Deletes[0].Status)
+	assert.Equal(t, proto.Status_INVALID_KEY, res.DeleteRanges[0].Status)
+
+	// In a transaction, none of the operations is applied
+	res, err = lc.Write(&proto.WriteRequest{
+		ShardId: &shard,
+		Puts: []*proto.PutRequest{
+			{Key: "d", Value: []byte{0}},
+			{Key: common.InternalKeyPrefix + "term", Value: []byte("5")},
+		},
+		Transaction: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.Status_TRANSACTION_ABORTED, res.Puts[0].Status)
+	assert.Equal(t, proto.Status_INVALID_KEY, res.Puts[1].Status)
+
+	list := func(request *proto.ListRequest) []string {
+		request.ShardId = &shard
+		ch, err := lc.List(context.Background(), request)
+		assert.NoError(t, err)
+		keys := make([]string, 0)
+		for key := range ch {
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	// Only the user keys were written
+	assert.Equal(t, []string{"a", "c", "/a", "b/c"}, list(&proto.ListRequest{StartInclusive: "", EndExclusive: "\xff/"}))
+
+	// A delete range over all the keys is split around the internal keys. Its
+	// end must be valid UTF-8, unlike "\xff/", to be appended to the wal.
+	res, err = lc.Write(&proto.WriteRequest{
+		ShardId:      &shard,
+		DeleteRanges: []*proto.DeleteRangeRequest{{StartInclusive: "", EndExclusive: "\U0010FFFF/"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res.DeleteRanges, 1)
+	assert.Equal(t, proto.Status_OK, res.DeleteRanges[0].Status)
+	assert.Empty(t, list(&proto.ListRequest{StartInclusive: "", EndExclusive: "\xff/"}))
+
+	// The shard still works, since its internal keys were not deleted
+	_, err = lc.Write(&proto.WriteRequest{
+		ShardId: &shard,
+		Puts:    []*proto.PutRequest{{Key: "/a", Value: []byte{1}}},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, lc.Close())
+	assert.NoError(t, kvFactory.Close())
+	assert.NoError(t, walFactory.Close())
+}
+
 func TestLeaderController_DeleteShard(t *testing.T) {
 	var shard int64 = 1
 
@@ -1071,3 +1216,21 @@ func TestLeaderController_GetStatus(t *testing.T) {
 	assert.NoError(t, kvFactory.Close())
 	assert.NoError(t, walFactory.Close())
 }
+
+func TestLeaderController_CheckSequentialKeys(t *testing.T) {
+	for _, item := range []struct {
+		name string
+		put  *proto.PutRequest
+		err  error
+	}{
+		{"no deltas", &proto.PutRequest{Key: "a"}, nil},
+		{"valid deltas", &proto.PutRequest{Key: "a", SequenceKeyDelta: []uint64{1, 0}}, nil},
+		{"first delta is zero", &proto.PutRequest{Key: "a", SequenceKeyDelta: []uint64{0, 1}}, common.ErrorInvalidSequentialKeys},
+		{"expected version", &proto.PutRequest{Key: "a", SequenceKeyDelta: []uint64{1}, ExpectedVersionId: pb.Int64(-1)}, common.ErrorInvalidSequentialKeys},
+	} {
+		t.Run(item.name, func(t *testing.T) {
+			err := checkSequentialKeys(&proto.WriteRequest{Puts: []*proto.PutRequest{item.put}})
+			assert.Equal(t, item.err, err)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"oxia/common"
+	"oxia/proto"
+	"strings"
+)
+
+// notificationsFilter selects the notifications that a client has subscribed
+// to. A key needs to match all the filters that are set in the request.
+// The values and the previous versions are only sent when they are requested.
+type notificationsFilter struct {
+	prefixes               []string
+	minKey                 *string
+	maxKey                 *string
+	hasCriteria            bool
+	includeValues          bool
+	includePreviousVersion bool
+}
+
+func newNotificationsFilter(req *proto.NotificationsRequest) *notificationsFilter {
+	return &notificationsFilter{
+		prefixes:    req.KeyPrefixes,
+		minKey:      req.KeyMinInclusive,
+		maxKey:      req.KeyMaxExclusive,
+		hasCriteria: len(req.KeyPrefixes) > 0 || req.KeyMinInclusive != nil || req.KeyMaxExclusive != nil,
+
+		includeValues:          req.IncludeValues,
+		includePreviousVersion: req.IncludePreviousVersion,
+	}
+}
+
+func (f *notificationsFilter) matches(key string) bool {
+	if f.minKey != nil && common.CompareWithSlash([]byte(key), []byte(*f.minKey)) < 0 {
+		return false
+	}
+	if f.maxKey != nil && common.CompareWithSlash([]byte(key), []byte(*f.maxKey)) >= 0 {
+		return false
+	}
+	if len(f.prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the batch with only the matching notifications, or nil if
+// none of them is matching
+func (f *notificationsFilter) apply(nb *proto.NotificationBatch) *proto.NotificationBatch {
+	if !f.hasCriteria && f.includeValues && f.includePreviousVersion {
+		return nb
+	}
+
+	filtered := make(map[string]*proto.Notification)
+	for key, n := range nb.Notifications {
+		if f.matches(key) {
+			filtered[key] = f.strip(n)
+		}
+	}
+
+	if len(filtered) == 0 && len(nb.Notifications) > 0 {
+		return nil
+	}
+
+	return &proto.NotificationBatch{
+		ShardId:       nb.ShardId,
+		Offset:        nb.Offset,
+		Timestamp:     nb.Timestamp,
+		Notifications: filtered,
+	}
+}
+
+// strip removes the fields that the client has not requested
+func (f *notificationsFilter) strip(n *proto.Notification) *proto.Notification {
+	if (f.includeValues || n.Value == nil) && (f.includePreviousVersion || n.PreviousVersionId == nil) {
+		return n
+	}
+
+	stripped := &proto.Notification{
+		Type:      n.Type,
+		VersionId: n.VersionId,
+	}
+	if f.includeValues {
+		stripped.Value = n.Value
+	}
+	if f.includePreviousVersion {
+		stripped.PreviousVersionId = n.PreviousVersionId
+	}
+	return stripped
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/stretchr/testify/assert"
+	pb "google.golang.org/protobuf/proto"
+	"oxia/proto"
+	"testing"
+)
+
+func TestNotificationsFilter(t *testing.T) {
+	nb := &proto.NotificationBatch{
+		ShardId: 1,
+		Offset:  5,
+		Notifications: map[string]*proto.Notification{
+			"/a/1": {Type: proto.NotificationType_KEY_CREATED},
+			"/a/2": {Type: proto.NotificationType_KEY_MODIFIED},
+			"/b/1": {Type: proto.NotificationType_KEY_DELETED},
+			"/c":   {Type: proto.NotificationType_KEY_CREATED},
+		},
+	}
+
+	// No filter
+	filtered := newNotificationsFilter(&proto.NotificationsRequest{}).apply(nb)
+	assert.Equal(t, nb, filtered)
+
+	filtered = newNotificationsFilter(&proto.NotificationsRequest{
+		KeyPrefixes: []string{"/a/", "/c"},
+	}).apply(nb)
+	assert.EqualValues(t, 5, filtered.Offset)
+	assert.Len(t, filtered.Notifications, 3)
+	assert.Contains(t, filtered.Notifications, "/a/1")
+	assert.Contains(t, filtered.Notifications, "/a/2")
+	assert.Contains(t, filtered.Notifications, "/c")
+
+	filtered = newNotificationsFilter(&proto.NotificationsRequest{
+		KeyMinInclusive: pb.String("/a/2"),
+		KeyMaxExclusive: pb.String("/b/2"),
+	}).apply(nb)
+	assert.Len(t, filtered.Notifications, 2)
+	assert.Contains(t, filtered.Notifications, "/a/2")
+	assert.Contains(t, filtered.Notifications, "/b/1")
+
+	// Both the prefix and the range must match
+	filtered = newNotificationsFilter(&proto.NotificationsRequest{
+		KeyPrefixes:     []string{"/a/"},
+		KeyMinInclusive: pb.String("/a/2"),
+	}).apply(nb)
+	assert.Len(t, filtered.Notifications, 1)
+	assert.Contains(t, filtered.Notifications, "/a/2")
+
+	filtered = newNotificationsFilter(&proto.NotificationsRequest{
+		KeyPrefixes: []string{"/x/"},
+	}).apply(nb)
+	assert.Nil(t, filtered)
+}
+
+func TestNotificationsFilter_IncludeFields(t *testing.T) {
+	nb := &proto.NotificationBatch{
+		ShardId: 1,
+		Offset:  5,
+		Notifications: map[string]*proto.Notification{
+			"/a": {
+				Type:              proto.NotificationType_KEY_MODIFIED,
+				VersionId:         pb.Int64(5),
+				Value:             []byte("value"),
+				PreviousVersionId: pb.Int64(2),
+			},
+		},
+	}
+
+	filtered := newNotificationsFilter(&proto.NotificationsRequest{}).apply(nb)
+	n := filtered.Notifications["/a"]
+	assert.EqualValues(t, 5, *n.VersionId)
+	assert.Nil(t, n.Value)
+	assert.Nil(t, n.PreviousVersionId)
+
+	filtered = newNotificationsFilter(&proto.NotificationsRequest{IncludeValues: true}).apply(nb)
+	n = filtered.Notifications["/a"]
+	assert.Equal(t, "value", string(n.Value))
+	assert.Nil(t, n.PreviousVersionId)
+
+	filtered = newNotificationsFilter(&proto.NotificationsRequest{
+		IncludeValues:          true,
+		IncludePreviousVersion: true,
+	}).apply(nb)
+	assert.Equal(t, nb, filtered)
+}
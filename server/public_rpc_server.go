@@ -16,6 +16,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -24,6 +25,7 @@ import (
 	"google.golang.org/protobuf/encoding/protowire"
 	"oxia/common"
 	"oxia/common/container"
+	"oxia/common/security"
 	"oxia/proto"
 )
 
@@ -37,23 +39,35 @@ type publicRpcServer struct {
 
 	shardsDirector       ShardsDirector
 	assignmentDispatcher ShardAssignmentsDispatcher
+	authorization        *authorization
 	grpcServer           container.GrpcServer
 	log                  zerolog.Logger
 }
 
-func newPublicRpcServer(provider container.GrpcProvider, bindAddress string, shardsDirector ShardsDirector, assignmentDispatcher ShardAssignmentsDispatcher) (*publicRpcServer, error) {
+// newPublicRpcServer starts the public service. When the authenticator is set,
+// only the calls that carry a valid bearer token are accepted. When the
+// authorizer is set, the calls must also be allowed by the roles of the client.
+func newPublicRpcServer(provider container.GrpcProvider, bindAddress string, tlsConf *tls.Config,
+	authenticator security.Authenticator, authorizer *security.Authorizer, shardsDirector ShardsDirector,
+	assignmentDispatcher ShardAssignmentsDispatcher) (*publicRpcServer, error) {
 	server := &publicRpcServer{
 		shardsDirector:       shardsDirector,
 		assignmentDispatcher: assignmentDispatcher,
+		authorization:        newAuthorization(authorizer),
 		log: log.With().
 			Str("component", "public-rpc-server").
 			Logger(),
 	}
 
+	var options []grpc.ServerOption
+	if authenticator != nil {
+		options = security.NewAuthenticationInterceptor(authenticator).ServerOptions()
+	}
+
 	var err error
 	server.grpcServer, err = provider.StartGrpcServer("public", bindAddress, func(registrar grpc.ServiceRegistrar) {
 		proto.RegisterOxiaClientServer(registrar, server)
-	})
+	}, tlsConf, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +100,14 @@ func (s *publicRpcServer) Write(ctx context.Context, write *proto.WriteRequest)
 		return nil, err
 	}
 
-	wr, err := lc.Write(write)
+	// The operations on keys that are not allowed are reported as denied,
+	// without failing the other operations of the request
+	denials := deniedWrites(s.authorization.permissions(ctx, lc, security.OperationWrite), write)
+	if denials.count > 0 {
+		s.authorization.denyOperations(ctx, lc, security.OperationWrite, denials.count)
+	}
+
+	wr, err := denials.apply(write, lc.Write)
 	if err != nil {
 		s.log.Warn().Err(err).
 			Msg("Failed to perform write operation")
@@ -106,15 +127,32 @@ func (s *publicRpcServer) Read(request *proto.ReadRequest, stream proto.OxiaClie
 		return err
 	}
 
+	// The gets of keys that are not allowed are reported as denied, in their
+	// position within the results of the other gets
+	permissions := s.authorization.permissions(stream.Context(), lc, security.OperationRead)
+	denied, deniedCount := deniedGets(permissions, request)
+	if deniedCount > 0 {
+		s.authorization.denyOperations(stream.Context(), lc, security.OperationRead, deniedCount)
+		request.Gets = withoutRejected(denied, request.Gets)
+	}
+
 	ch := lc.Read(stream.Context(), request)
 
 	response := &proto.ReadResponse{}
 	var totalSize int
+	next := 0
+
+	addDenied := func() {
+		for ; next < len(denied) && denied[next] != proto.Status_OK; next++ {
+			response.Gets = append(response.Gets, &proto.GetResponse{Status: denied[next]})
+		}
+	}
 
 	for {
 		select {
 		case result, more := <-ch:
 			if !more {
+				addDenied()
 				if len(response.Gets) > 0 {
 					if err := stream.Send(response); err != nil {
 						return err
@@ -125,6 +163,8 @@ func (s *publicRpcServer) Read(request *proto.ReadRequest, stream proto.OxiaClie
 			if result.Err != nil {
 				return result.Err
 			}
+			addDenied()
+			next++
 			size := protowire.SizeBytes(len(result.Response.Value))
 			if len(response.Gets) > 0 && totalSize+size > maxTotalReadValueSize {
 				if err := stream.Send(response); err != nil {
@@ -152,10 +192,16 @@ func (s *publicRpcServer) List(request *proto.ListRequest, stream proto.OxiaClie
 		return err
 	}
 
+	if !s.authorization.permissions(stream.Context(), lc, security.OperationList).
+		AllowsRange(request.StartInclusive, request.EndExclusive) {
+		return s.authorization.deny(stream.Context(), lc, security.OperationList)
+	}
+
 	ch, err := lc.List(stream.Context(), request)
 	if err != nil {
 		s.log.Warn().Err(err).
 			Msg("Failed to perform list operation")
+		return err
 	}
 
 	response := &proto.ListResponse{}
@@ -188,6 +234,87 @@ func (s *publicRpcServer) List(request *proto.ListRequest, stream proto.OxiaClie
 	}
 }
 
+func (s *publicRpcServer) RangeScan(request *proto.RangeScanRequest, stream proto.OxiaClient_RangeScanServer) error {
+	s.log.Debug().
+		Str("peer", common.GetPeer(stream.Context())).
+		Interface("req", request).
+		Msg("Range scan request")
+
+	lc, err := s.getLeader(*request.ShardId)
+	if err != nil {
+		return err
+	}
+
+	if !s.authorization.permissions(stream.Context(), lc, security.OperationRead).
+		AllowsRange(request.StartInclusive, request.EndExclusive) {
+		return s.authorization.deny(stream.Context(), lc, security.OperationRead)
+	}
+
+	ch, err := lc.RangeScan(stream.Context(), request)
+	if err != nil {
+		s.log.Warn().Err(err).
+			Msg("Failed to perform range scan operation")
+		return err
+	}
+
+	response := &proto.RangeScanResponse{}
+	var totalSize int
+
+	for {
+		select {
+		case result, more := <-ch:
+			if !more {
+				if len(response.Records) > 0 {
+					if err := stream.Send(response); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			if result.Err != nil {
+				return result.Err
+			}
+			size := protowire.SizeBytes(len(result.Response.GetKey())) + protowire.SizeBytes(len(result.Response.Value))
+			if len(response.Records) > 0 && totalSize+size > maxTotalReadValueSize {
+				if err := stream.Send(response); err != nil {
+					return err
+				}
+				response = &proto.RangeScanResponse{}
+				totalSize = 0
+			}
+			response.Records = append(response.Records, result.Response)
+			totalSize += size
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *publicRpcServer) CountRange(ctx context.Context, request *proto.CountRangeRequest) (*proto.CountRangeResponse, error) {
+	s.log.Debug().
+		Str("peer", common.GetPeer(ctx)).
+		Interface("req", request).
+		Msg("Count range request")
+
+	lc, err := s.getLeader(*request.ShardId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.authorization.permissions(ctx, lc, security.OperationList).
+		AllowsRange(request.StartInclusive, request.EndExclusive) {
+		return nil, s.authorization.deny(ctx, lc, security.OperationList)
+	}
+
+	response, err := lc.CountRange(ctx, request)
+	if err != nil {
+		s.log.Warn().Err(err).
+			Msg("Failed to perform count range operation")
+	}
+
+	return response, err
+}
+
 func (s *publicRpcServer) GetNotifications(req *proto.NotificationsRequest, stream proto.OxiaClient_GetNotificationsServer) error {
 	s.log.Debug().
 		Str("peer", common.GetPeer(stream.Context())).
@@ -199,6 +326,10 @@ func (s *publicRpcServer) GetNotifications(req *proto.NotificationsRequest, stre
 		return err
 	}
 
+	if !allowsNotifications(s.authorization.permissions(stream.Context(), lc, security.OperationNotifications), req) {
+		return s.authorization.deny(stream.Context(), lc, security.OperationNotifications)
+	}
+
 	if err = lc.GetNotifications(req, stream); err != nil && !errors.Is(err, context.Canceled) {
 		s.log.Warn().Err(err).
 			Msg("Failed to handle notifications request")
@@ -220,6 +351,9 @@ func (s *publicRpcServer) CreateSession(ctx context.Context, req *proto.CreateSe
 	if err != nil {
 		return nil, err
 	}
+	if !allowsNamespace(s.authorization.permissions(ctx, lc, security.OperationSession)) {
+		return nil, s.authorization.deny(ctx, lc, security.OperationSession)
+	}
 	res, err := lc.CreateSession(req)
 	if err != nil {
 		s.log.Warn().Err(err).
@@ -239,6 +373,9 @@ func (s *publicRpcServer) KeepAlive(ctx context.Context, req *proto.SessionHeart
 	if err != nil {
 		return nil, err
 	}
+	if !allowsNamespace(s.authorization.permissions(ctx, lc, security.OperationSession)) {
+		return nil, s.authorization.deny(ctx, lc, security.OperationSession)
+	}
 	err = lc.KeepAlive(req.SessionId)
 	if err != nil {
 		s.log.Warn().Err(err).
@@ -257,6 +394,9 @@ func (s *publicRpcServer) CloseSession(ctx context.Context, req *proto.CloseSess
 	if err != nil {
 		return nil, err
 	}
+	if !allowsNamespace(s.authorization.permissions(ctx, lc, security.OperationSession)) {
+		return nil, s.authorization.deny(ctx, lc, security.OperationSession)
+	}
 	res, err := lc.CloseSession(req)
 	if err != nil {
 		s.log.Warn().Err(err).
@@ -16,6 +16,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"google.golang.org/grpc/metadata"
 	"io"
@@ -37,9 +38,11 @@ type replicationRpcProvider struct {
 	pool common.ClientPool
 }
 
-func NewReplicationRpcProvider() ReplicationRpcProvider {
+// NewReplicationRpcProvider creates the provider of the connections to the
+// followers. When `tlsConf` is nil, the connections are established in plaintext
+func NewReplicationRpcProvider(tlsConf *tls.Config) ReplicationRpcProvider {
 	return &replicationRpcProvider{
-		pool: common.NewClientPool(),
+		pool: common.NewClientPool(tlsConf, nil),
 	}
 }
 
@@ -15,10 +15,12 @@
 package server
 
 import (
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"go.uber.org/multierr"
 	"oxia/common/container"
 	"oxia/common/metrics"
+	"oxia/common/security"
 	"oxia/server/kv"
 	"oxia/server/wal"
 	"time"
@@ -33,8 +35,39 @@ type Config struct {
 
 	WalRetentionTime           time.Duration
 	NotificationsRetentionTime time.Duration
+	// NotificationsIncludeValues stores the new values of the records in the
+	// notifications, for the clients that subscribe with IncludeValues. It is
+	// disabled by default, since the values are kept for the whole retention
+	// time of the notifications
+	NotificationsIncludeValues bool
+
+	// PublicServerTLS configures the TLS of the public service
+	PublicServerTLS security.TLSOptions
+	// PublicServerAuth configures the authentication of the clients of the
+	// public service
+	PublicServerAuth security.AuthenticationOptions
+	// PublicServerAuthorizationFile contains the roles that restrict the
+	// operations of the authenticated clients of the public service, per
+	// namespace and key prefix. When empty, all the operations are allowed.
+	// It requires PublicServerAuth
+	PublicServerAuthorizationFile string
+	// InternalServerTLS configures the TLS of the internal service
+	InternalServerTLS security.TLSOptions
+	// InternalAllowedPeers are the identities of the certificates that the
+	// coordinator and the other servers present to the internal service. When
+	// empty, any certificate signed by the trusted CA is accepted. It requires
+	// InternalServerTLS.RequireClientCert
+	InternalAllowedPeers []string
+	// PeerTLS configures the TLS of the connections to the internal service
+	// of the other servers, for the replication
+	PeerTLS security.TLSOptions
 }
 
+var (
+	ErrorAllowedPeersWithoutClientCert      = errors.New("the internal allowed peers require the internal service to require client certificates")
+	ErrorAuthorizationWithoutAuthentication = errors.New("the authorization of the public service requires the authentication of its clients")
+)
+
 type Server struct {
 	*internalRpcServer
 	*publicRpcServer
@@ -48,7 +81,11 @@ type Server struct {
 }
 
 func New(config Config) (*Server, error) {
-	return NewWithGrpcProvider(config, container.Default, NewReplicationRpcProvider())
+	peerTLSConf, err := config.PeerTLS.MakeClientTLSConf()
+	if err != nil {
+		return nil, err
+	}
+	return NewWithGrpcProvider(config, container.Default, NewReplicationRpcProvider(peerTLSConf))
 }
 
 func NewWithGrpcProvider(config Config, provider container.GrpcProvider, replicationRpcProvider ReplicationRpcProvider) (*Server, error) {
@@ -56,6 +93,41 @@ func NewWithGrpcProvider(config Config, provider container.GrpcProvider, replica
 		Interface("config", config).
 		Msg("Starting Oxia server")
 
+	publicServerTLSConf, err := config.PublicServerTLS.MakeServerTLSConf()
+	if err != nil {
+		return nil, err
+	}
+
+	authenticator, err := security.NewAuthenticator(config.PublicServerAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := newAuthorizer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client certificates of the internal service are required by the peer
+	// verifier instead of the handshake, which only verifies the ones that are
+	// presented, so that the health probes can connect without one
+	internalTLS := config.InternalServerTLS
+	var peerVerifier *security.PeerVerifier
+	if internalTLS.RequireClientCert {
+		if internalTLS.TrustedCaFile == "" {
+			return nil, security.ErrorMissingTrustedCa
+		}
+		peerVerifier = security.NewPeerVerifier(config.InternalAllowedPeers)
+		internalTLS.RequireClientCert = false
+	} else if len(config.InternalAllowedPeers) > 0 {
+		return nil, ErrorAllowedPeersWithoutClientCert
+	}
+
+	internalServerTLSConf, err := internalTLS.MakeServerTLSConf()
+	if err != nil {
+		return nil, err
+	}
+
 	kvFactory, err := kv.NewPebbleKVFactory(&kv.KVFactoryOptions{
 		DataDir:   config.DataDir,
 		CacheSize: 100 * 1024 * 1024,
@@ -75,14 +147,14 @@ func NewWithGrpcProvider(config Config, provider container.GrpcProvider, replica
 	s.shardsDirector = NewShardsDirector(config, s.walFactory, s.kvFactory, replicationRpcProvider)
 	s.shardAssignmentDispatcher = NewShardAssignmentDispatcher()
 
-	s.internalRpcServer, err = newInternalRpcServer(provider, config.InternalServiceAddr,
+	s.internalRpcServer, err = newInternalRpcServer(provider, config.InternalServiceAddr, internalServerTLSConf, peerVerifier,
 		s.shardsDirector, s.shardAssignmentDispatcher)
 	if err != nil {
 		return nil, err
 	}
 
-	s.publicRpcServer, err = newPublicRpcServer(provider, config.PublicServiceAddr, s.shardsDirector,
-		s.shardAssignmentDispatcher)
+	s.publicRpcServer, err = newPublicRpcServer(provider, config.PublicServiceAddr, publicServerTLSConf, authenticator,
+		authorizer, s.shardsDirector, s.shardAssignmentDispatcher)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +168,22 @@ func NewWithGrpcProvider(config Config, provider container.GrpcProvider, replica
 	return s, nil
 }
 
+// newAuthorizer loads the authorization rules of the public service, if any
+func newAuthorizer(config Config) (*security.Authorizer, error) {
+	if config.PublicServerAuthorizationFile == "" {
+		return nil, nil
+	}
+	if config.PublicServerAuth.Provider == security.AuthenticationProviderNone {
+		return nil, ErrorAuthorizationWithoutAuthentication
+	}
+
+	authorizationConfig, err := security.LoadAuthorizationConfig(config.PublicServerAuthorizationFile)
+	if err != nil {
+		return nil, err
+	}
+	return security.NewAuthorizer(*authorizationConfig)
+}
+
 func (s *Server) PublicPort() int {
 	return s.publicRpcServer.grpcServer.Port()
 }
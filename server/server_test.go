@@ -15,11 +15,29 @@
 package server
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"io"
+	"math/big"
 	"net/http"
+	"os"
+	"oxia/common/security"
+	"oxia/proto"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestNewServer(t *testing.T) {
@@ -44,3 +62,71 @@ func TestNewServer(t *testing.T) {
 	// Looks like exposition format
 	assert.Equal(t, "# HELP ", string(body[0:7]))
 }
+
+func TestNewServer_InternalHealthWithoutClientCert(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t)
+	config := Config{
+		InternalServiceAddr: "localhost:0",
+		PublicServiceAddr:   "localhost:0",
+		MetricsServiceAddr:  "localhost:0",
+		DataDir:             t.TempDir(),
+		WalDir:              t.TempDir(),
+		InternalServerTLS: security.TLSOptions{
+			CertFile:          certFile,
+			KeyFile:           keyFile,
+			TrustedCaFile:     certFile,
+			RequireClientCert: true,
+		},
+	}
+
+	server, err := New(config)
+	assert.NoError(t, err)
+
+	// The client doesn't present any certificate
+	clientConf, err := (&security.TLSOptions{TrustedCaFile: certFile, ServerName: "localhost"}).MakeClientTLSConf()
+	assert.NoError(t, err)
+	cnx, err := grpc.Dial(fmt.Sprintf("localhost:%d", server.InternalPort()),
+		grpc.WithTransportCredentials(credentials.NewTLS(clientConf)))
+	assert.NoError(t, err)
+
+	health, err := grpc_health_v1.NewHealthClient(cnx).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, health.Status)
+
+	_, err = proto.NewOxiaCoordinationClient(cnx).NewTerm(context.Background(), &proto.NewTermRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	assert.NoError(t, cnx.Close())
+	assert.NoError(t, server.Close())
+}
+
+// writeSelfSignedCert writes a certificate valid for `localhost`, which is also
+// its own CA, and returns the paths of the certificate and key files
+func writeSelfSignedCert(t *testing.T) (certFile string, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "server"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "server.crt")
+	keyFile = filepath.Join(dir, "server.key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
@@ -92,18 +92,22 @@ func (s *session) delete() error {
 			})
 		}
 	}
-	_, err = s.sm.leaderController.Write(&proto.WriteRequest{
-		ShardId: &s.shardId,
-		Puts:    nil,
-		Deletes: deletes,
-		// Delete the index and the session keys
-		DeleteRanges: []*proto.DeleteRangeRequest{
-			{
-				StartInclusive: sessionKey,
-				EndExclusive:   sessionKey + "/",
+	// The session keys are internal, so the request bypasses the validation of
+	// the client writes
+	_, _, err = s.sm.leaderController.write(func(_ int64) *proto.WriteRequest {
+		return &proto.WriteRequest{
+			ShardId: &s.shardId,
+			Puts:    nil,
+			Deletes: deletes,
+			// Delete the index and the session keys
+			DeleteRanges: []*proto.DeleteRangeRequest{
+				{
+					StartInclusive: sessionKey,
+					EndExclusive:   sessionKey + "/",
+				},
 			},
-		},
-	})
+		}
+	}, false)
 	s.log.Debug().Msg("Session deleted")
 	return err
 }
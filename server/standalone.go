@@ -21,6 +21,7 @@ import (
 	"oxia/common"
 	"oxia/common/container"
 	"oxia/common/metrics"
+	"oxia/common/security"
 	"oxia/proto"
 	"oxia/server/kv"
 	"oxia/server/wal"
@@ -81,7 +82,23 @@ func NewStandalone(config StandaloneConfig) (*Standalone, error) {
 		return nil, err
 	}
 
-	s.rpc, err = newPublicRpcServer(container.Default, config.PublicServiceAddr, s.shardsDirector, nil)
+	publicServerTLSConf, err := config.PublicServerTLS.MakeServerTLSConf()
+	if err != nil {
+		return nil, err
+	}
+
+	authenticator, err := security.NewAuthenticator(config.PublicServerAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := newAuthorizer(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rpc, err = newPublicRpcServer(container.Default, config.PublicServiceAddr, publicServerTLSConf, authenticator,
+		authorizer, s.shardsDirector, nil)
 	if err != nil {
 		return nil, err
 	}
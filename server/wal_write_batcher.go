@@ -39,6 +39,10 @@ type writeTask struct {
 	actualRequest *proto.WriteRequest
 	flush         bool
 	result        chan *writeResult
+
+	// orphanedExpirationIndexKeys are removed along with the request, when the
+	// log entry is applied
+	orphanedExpirationIndexKeys []string
 }
 
 func NewWriteTask(request func(int64) *proto.WriteRequest, flush bool) *writeTask {
@@ -89,10 +93,12 @@ func (l *walWriteBatch) Complete() {
 	timestamp := uint64(time.Now().UnixMilli())
 
 	var requests []*proto.WriteRequest
+	var orphanedExpirationIndexKeys []string
 	for _, task := range l.tasks {
 		task.actualRequest = task.request(newOffset)
 
 		requests = append(requests, task.actualRequest)
+		orphanedExpirationIndexKeys = append(orphanedExpirationIndexKeys, task.orphanedExpirationIndexKeys...)
 
 		l.log.Debug().
 			Interface("req", task.actualRequest).
@@ -102,7 +108,8 @@ func (l *walWriteBatch) Complete() {
 	value := &proto.LogEntryValue{
 		Value: &proto.LogEntryValue_Requests{
 			Requests: &proto.WriteRequests{
-				Writes: requests,
+				Writes:                      requests,
+				OrphanedExpirationIndexKeys: orphanedExpirationIndexKeys,
 			},
 		}}
 	marshalled, err := pb.Marshal(value)
@@ -114,10 +121,12 @@ func (l *walWriteBatch) Complete() {
 	}
 	if err != nil {
 		l.Fail(errors.Wrap(err, "oxia: failed to marshal log entry"))
+		return
 	}
 
 	if err = l.wal.AppendAsync(logEntry); err != nil {
 		l.Fail(errors.Wrap(err, "oxia: failed to append to wal"))
+		return
 	}
 
 	go func() {
@@ -126,6 +135,7 @@ func (l *walWriteBatch) Complete() {
 		// sync requests
 		if err = l.wal.Sync(l.ctx); err != nil {
 			l.Fail(errors.Wrap(err, "oxia: failed to sync the wal"))
+			return
 		}
 
 		l.quorumAckTracker.AdvanceHeadOffset(newOffset)
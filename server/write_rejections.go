@@ -0,0 +1,154 @@
+// Copyright 2023 StreamNative, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"oxia/proto"
+)
+
+// writeRejections holds the status of the operations of a write request that
+// are rejected before being applied, in the order of the request. The status
+// is OK for the operations that can be applied.
+type writeRejections struct {
+	puts         []proto.Status
+	deletes      []proto.Status
+	deleteRanges []proto.Status
+	count        int
+}
+
+// rejectWrites checks all the operations of the request
+func rejectWrites(request *proto.WriteRequest,
+	checkPut func(*proto.PutRequest) proto.Status,
+	checkDelete func(*proto.DeleteRequest) proto.Status,
+	checkDeleteRange func(*proto.DeleteRangeRequest) proto.Status) *writeRejections {
+	r := &writeRejections{}
+	r.puts = checkOperations(request.Puts, checkPut, &r.count)
+	r.deletes = checkOperations(request.Deletes, checkDelete, &r.count)
+	r.deleteRanges = checkOperations(request.DeleteRanges, checkDeleteRange, &r.count)
+	return r
+}
+
+// all returns true when none of the operations can be applied
+func (r *writeRejections) all() bool {
+	return r.count == len(r.puts)+len(r.deletes)+len(r.deleteRanges)
+}
+
+// apply writes the operations of the request that are not rejected, and
+// returns the response of all of them. A request whose operations are all
+// rejected, or a transaction with a rejected operation, is not written.
+func (r *writeRejections) apply(request *proto.WriteRequest,
+	write func(*proto.WriteRequest) (*proto.WriteResponse, error)) (*proto.WriteResponse, error) {
+	if r.count > 0 {
+		if request.Transaction || r.all() {
+			return r.reject(), nil
+		}
+		r.remove(request)
+	}
+
+	response, err := write(request)
+	if err != nil {
+		return nil, err
+	}
+	return r.merge(response), nil
+}
+
+// remove removes the rejected operations from the request
+func (r *writeRejections) remove(request *proto.WriteRequest) {
+	request.Puts = withoutRejected(r.puts, request.Puts)
+	request.Deletes = withoutRejected(r.deletes, request.Deletes)
+	request.DeleteRanges = withoutRejected(r.deleteRanges, request.DeleteRanges)
+}
+
+// merge adds the responses of the rejected operations to the response of the
+// request without them
+func (r *writeRejections) merge(response *proto.WriteResponse) *proto.WriteResponse {
+	if r.count == 0 {
+		return response
+	}
+	response.Puts = withRejected(r.puts, response.Puts, newPutResponse)
+	response.Deletes = withRejected(r.deletes, response.Deletes, newDeleteResponse)
+	response.DeleteRanges = withRejected(r.deleteRanges, response.DeleteRanges, newDeleteRangeResponse)
+	return response
+}
+
+// reject returns the response of a request that is not applied at all, either
+// because all its operations are rejected or because it is a transaction. The
+// operations that were not rejected are reported as aborted.
+func (r *writeRejections) reject() *proto.WriteResponse {
+	return &proto.WriteResponse{
+		Puts:         withRejected(aborted(r.puts), nil, newPutResponse),
+		Deletes:      withRejected(aborted(r.deletes), nil, newDeleteResponse),
+		DeleteRanges: withRejected(aborted(r.deleteRanges), nil, newDeleteRangeResponse),
+	}
+}
+
+func checkOperations[T any](operations []T, check func(T) proto.Status, count *int) []proto.Status {
+	statuses := make([]proto.Status, len(operations))
+	for i, operation := range operations {
+		if statuses[i] = check(operation); statuses[i] != proto.Status_OK {
+			*count++
+		}
+	}
+	return statuses
+}
+
+func withoutRejected[T any](statuses []proto.Status, operations []T) []T {
+	accepted := make([]T, 0, len(operations))
+	for i, operation := range operations {
+		if statuses[i] == proto.Status_OK {
+			accepted = append(accepted, operation)
+		}
+	}
+	return accepted
+}
+
+// withRejected interleaves the responses of the accepted operations, which are
+// in the same order as the operations, with the ones of the rejected operations
+func withRejected[T any](statuses []proto.Status, responses []T, newResponse func(proto.Status) T) []T {
+	merged := make([]T, 0, len(statuses))
+	next := 0
+	for _, status := range statuses {
+		if status == proto.Status_OK {
+			merged = append(merged, responses[next])
+			next++
+		} else {
+			merged = append(merged, newResponse(status))
+		}
+	}
+	return merged
+}
+
+func aborted(statuses []proto.Status) []proto.Status {
+	res := make([]proto.Status, len(statuses))
+	for i, status := range statuses {
+		if status == proto.Status_OK {
+			status = proto.Status_TRANSACTION_ABORTED
+		}
+		res[i] = status
+	}
+	return res
+}
+
+func newPutResponse(status proto.Status) *proto.PutResponse {
+	return &proto.PutResponse{Status: status}
+}
+
+func newDeleteResponse(status proto.Status) *proto.DeleteResponse {
+	return &proto.DeleteResponse{Status: status}
+}
+
+func newDeleteRangeResponse(status proto.Status) *proto.DeleteRangeResponse {
+	return &proto.DeleteRangeResponse{Status: status}
+}